@@ -1,30 +1,42 @@
 package main
 
 import (
-	"bufio"
-	"bytes"
 	"flag"
 	"fmt"
 	l "github.com/reddragon/lambda/lang"
 	"github.com/tiborvass/uniline"
-	"os"
+	"io/ioutil"
+	"strings"
 )
 
-func process(env *l.LangEnv, line string) {
-	evalResult := l.Eval(line, env)
-	tokens := evalResult.RemainingTokens
-	if len(evalResult.ErrStr) > 0 {
-		fmt.Printf("Error: %s\n", evalResult.ErrStr)
-	} else {
+func process(env *l.LangEnv, src *l.Source) {
+	for src.More() {
+		evalResult := src.Eval(env)
+		if len(evalResult.ErrStr) > 0 {
+			printError(src, evalResult)
+			return
+		}
+		// Nothing is printed for comments, or for output forms like display.
 		if len(evalResult.ValStr) > 0 {
 			fmt.Printf("%s\n", evalResult.ValStr)
-		} else {
-			fmt.Printf("\n")
 		}
+	}
+}
 
-		if len(tokens) > 0 {
-			process(env, tokens)
-		}
+// Prints the error, along with the line it happened in, and a caret pointing
+// at the offending expression.
+func printError(src *l.Source, evalResult *l.EvalResult) {
+	span := evalResult.ErrSpan
+	if span == nil {
+		fmt.Printf("Error: %s\n", evalResult.ErrStr)
+		return
+	}
+	if len(span.File) > 0 {
+		fmt.Printf("%s: ", span)
+	}
+	fmt.Printf("Error: %s\n", evalResult.ErrStr)
+	for _, line := range strings.Split(src.Excerpt(*span), "\n") {
+		fmt.Printf("    %s\n", line)
 	}
 }
 
@@ -39,7 +51,7 @@ func initREPL() {
 		if len(line) > 0 {
 			scanner.AddToHistory(line)
 			// printType(line)
-			process(env, line)
+			process(env, l.NewSource("", line))
 		}
 	}
 
@@ -51,23 +63,13 @@ func initREPL() {
 }
 
 func processScriptFile(scriptFilePath string) {
-	file, err := os.Open(scriptFilePath)
+	// The file is read as it is, line breaks and all, so that comments end
+	// where their lines end.
+	script, err := ioutil.ReadFile(scriptFilePath)
 	if err != nil {
 		panic(err)
 	}
-	defer file.Close()
-
-	var concBuf bytes.Buffer
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		concBuf.WriteString(scanner.Text())
-		concBuf.WriteString(" ")
-	}
-
-	if scanner.Err() != nil {
-		panic(scanner.Err())
-	}
-	process(l.NewEnv(), concBuf.String())
+	process(l.NewEnv(), l.NewSource(scriptFilePath, string(script)))
 }
 
 func main() {
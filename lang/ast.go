@@ -1,101 +1,417 @@
 package lang
 
 import (
-	"errors"
-	"fmt"
 	"strings"
+	"unicode"
 )
 
 // An AstNode either has a value, or has children.
 // isValue = 1, if its a value, otherwise false.
+// span is the part of the source the node was read from.
+// datum is set for value nodes which were made from a value, like the ones in
+// the expansion of a macro, rather than read from a source.
 type ASTNode struct {
 	isValue  bool
 	value    string
 	children []*ASTNode
+	span     Span
+	datum    Value
+}
+
+// A token is the smallest unit the reader deals with, like a bracket, a
+// string literal or a name.
+type token struct {
+	text string
+	span Span
 }
 
 const (
 	openBracket   string = "("
 	closedBracket string = ")"
+	openBrace     string = "{"
+	closedBrace   string = "}"
+	openSquare    string = "["
+	closedSquare  string = "]"
+	vectorPrefix  string = "#("
+	quoteMark     string = "'"
+	backquote     string = "`"
+	comma         string = ","
+	commaAt       string = ",@"
+	dot           string = "."
+
+	// Comments
+	blockCommentStart string = "#|"
+	blockCommentEnd   string = "|#"
+	datumComment      string = "#;"
+
+	// Character literals, like #\a
+	charPrefix string = "#\\"
 )
 
 func errStr(expected, found string) error {
-	return errors.New(fmt.Sprintf("Expected %s, got %s.", expected, found))
+	return newSyntaxError("Expected %s, got %s.", expected, found)
 }
 
-// This method gets you the AST of a given expression.
-func getAST(exp string) (*ASTNode, []string, error) {
-	tokens := tokenize(exp)
-	if len(tokens) == 0 {
-		return nil, nil, errors.New("Nothing to evaluate")
+// A scanner walks over the characters of a source, keeping track of the line
+// and column it is at.
+type scanner struct {
+	file  string
+	runes []rune
+	i     int
+	line  int
+	col   int
+}
+
+func (s *scanner) done() bool {
+	return s.i >= len(s.runes)
+}
+
+func (s *scanner) peek() rune {
+	return s.runes[s.i]
+}
+
+func (s *scanner) hasPrefix(prefix string) bool {
+	i := s.i
+	for _, r := range prefix {
+		if i >= len(s.runes) || s.runes[i] != r {
+			return false
+		}
+		i++
 	}
-	return buildAST(tokens)
+	return true
 }
 
-func tokenize(exp string) []string {
-	return strings.Fields(
-		strings.Replace(strings.Replace(exp, "(", " ( ", -1), ")", " ) ", -1),
-	)
+func (s *scanner) advance(n int) {
+	for ; n > 0 && !s.done(); n-- {
+		if s.runes[s.i] == '\n' {
+			s.line++
+			s.col = 1
+		} else {
+			s.col++
+		}
+		s.i++
+	}
+}
+
+// Returns the span from the given start position to where the scanner is.
+func (s *scanner) spanFrom(line, col int) Span {
+	return Span{File: s.file, Line: line, Col: col, EndLine: s.line, EndCol: s.col}
+}
+
+// This method splits an expression into tokens. Brackets and quote marks are
+// tokens of their own, string literals are kept whole (quotes, escapes and
+// all), and everything else is split at whitespace and the characters above.
+//
+// Comments are dropped here: a ; comments out the rest of the line, and
+// #| ... |# comments out everything in between, and can be nested. A #;
+// comments out the expression after it, so it is left to buildAST.
+func tokenize(file, exp string) ([]token, error) {
+	tokens := make([]token, 0)
+	s := &scanner{file: file, runes: []rune(exp), line: 1, col: 1}
+	for !s.done() {
+		r := s.peek()
+		line, col, start := s.line, s.col, s.i
+		switch {
+		case unicode.IsSpace(r):
+			s.advance(1)
+
+		case r == ';':
+			for !s.done() && s.peek() != '\n' {
+				s.advance(1)
+			}
+
+		case s.hasPrefix(blockCommentStart):
+			depth := 0
+			for !s.done() {
+				if s.hasPrefix(blockCommentStart) {
+					depth++
+					s.advance(2)
+				} else if s.hasPrefix(blockCommentEnd) {
+					depth--
+					s.advance(2)
+					if depth == 0 {
+						break
+					}
+				} else {
+					s.advance(1)
+				}
+			}
+			if depth != 0 {
+				return nil, withSpan(newSyntaxError("Unterminated comment"), s.spanFrom(line, col))
+			}
+
+		case s.hasPrefix(datumComment):
+			s.advance(2)
+			tokens = append(tokens, token{datumComment, s.spanFrom(line, col)})
+
+		case s.hasPrefix(charPrefix):
+			// The character right after the prefix is part of the literal,
+			// even if it is a bracket or a space, as in #\( or #\ .
+			s.advance(len(charPrefix) + 1)
+			for !s.done() && !isDelimiter(s.peek()) {
+				s.advance(1)
+			}
+			tokens = append(tokens, token{string(s.runes[start:s.i]), s.spanFrom(line, col)})
+
+		case s.hasPrefix(vectorPrefix):
+			s.advance(2)
+			tokens = append(tokens, token{vectorPrefix, s.spanFrom(line, col)})
+
+		case s.hasPrefix(commaAt):
+			s.advance(2)
+			tokens = append(tokens, token{commaAt, s.spanFrom(line, col)})
+
+		case strings.ContainsRune("(){}[]'`,", r):
+			s.advance(1)
+			tokens = append(tokens, token{string(r), s.spanFrom(line, col)})
+
+		case r == '"':
+			s.advance(1)
+			for !s.done() && s.peek() != '"' {
+				if s.peek() == '\\' {
+					s.advance(1)
+				}
+				s.advance(1)
+			}
+			if s.done() {
+				return nil, withSpan(newSyntaxError("Unterminated string"), s.spanFrom(line, col))
+			}
+			s.advance(1)
+			tokens = append(tokens, token{string(s.runes[start:s.i]), s.spanFrom(line, col)})
+
+		default:
+			for !s.done() && !isDelimiter(s.peek()) {
+				s.advance(1)
+			}
+			tokens = append(tokens, token{string(s.runes[start:s.i]), s.spanFrom(line, col)})
+		}
+	}
+	return tokens, nil
+}
+
+func isDelimiter(r rune) bool {
+	return unicode.IsSpace(r) || strings.ContainsRune("(){}[]'`,\";", r)
+}
+
+// The marks which are shorthands for a special form around the expression
+// after them, like 'x for (quote x).
+var readerMacros = map[string]string{
+	quoteMark: quote,
+	backquote: quasiquote,
+	comma:     unquote,
+	commaAt:   unquoteSplicing,
+}
+
+// This method drops any #; comments at the start of the tokens, along with
+// the expressions they comment out.
+func skipDatumComments(tokens []token) ([]token, error) {
+	for len(tokens) > 0 && tokens[0].text == datumComment {
+		var comment token
+		comment, tokens = pop(tokens)
+		if len(tokens) == 0 {
+			return tokens, withSpan(errStr("value after "+datumComment, "nil"), comment.span)
+		}
+		var err error
+		if _, tokens, err = buildAST(tokens); err != nil {
+			return tokens, err
+		}
+	}
+	return tokens, nil
 }
 
 // This method does the heavy-lifting of building an AST, once an expression
-// is tokenized.
-func buildAST(tokens []string) (*ASTNode, []string, error) {
-	var token = ""
-	tokensLen := len(tokens)
+// is tokenized. It reads exactly one expression, and returns the tokens that
+// were left over.
+func buildAST(tokens []token) (*ASTNode, []token, error) {
+	var tok token
 	// If it is an empty list of tokens, the AST is a nil node
-	if tokensLen == 0 {
+	if len(tokens) == 0 {
 		return nil, tokens, nil
-	} else if tokensLen == 1 {
-		// TODO Check that this token is a value.
-		//      A proxy for now is checking if this is not a ( or )
-		token, tokens = pop(tokens)
-		if token == openBracket || token == closedBracket {
-			return nil, tokens, errStr("value", token)
-		}
+	}
 
+	tokens, err := skipDatumComments(tokens)
+	if err != nil {
+		return nil, tokens, err
+	}
+	if len(tokens) == 0 {
+		return nil, tokens, errStr("value", "nil")
+	}
+
+	tok, tokens = pop(tokens)
+	switch tok.text {
+	case closedBracket, closedBrace, closedSquare:
+		return nil, tokens, withSpan(errStr("value", tok.text), tok.span)
+
+	case quoteMark, backquote, comma, commaAt:
+		// 'x is a shorthand for (quote x), `x for (quasiquote x), and so on.
+		if len(tokens) == 0 {
+			return nil, tokens, withSpan(errStr("value after "+tok.text, "nil"), tok.span)
+		}
+		quotedNode, tokens, err := buildAST(tokens)
+		if err != nil {
+			return nil, tokens, err
+		}
 		node := new(ASTNode)
-		node.isValue = true
-		node.value = token
-		node.children = nil
+		node.isValue = false
+		node.children = []*ASTNode{newValueNode(readerMacros[tok.text], tok.span), quotedNode}
+		node.span = joinSpans(tok.span, quotedNode.span)
+		return node, tokens, nil
+
+	case openBracket:
+		return buildList(tokens, tok, closedBracket)
+
+	case openBrace:
+		// {k v ...} is a shorthand for (hash k v ...).
+		node, tokens, err := buildList(tokens, tok, closedBrace)
+		if err != nil {
+			return nil, tokens, err
+		}
+		node.children = append([]*ASTNode{newValueNode(makeHash, tok.span)}, node.children...)
+		return node, tokens, nil
+
+	case vectorPrefix, openSquare:
+		// #(x ...) and [x ...] are shorthands for (vector x ...).
+		closing := closedBracket
+		if tok.text == openSquare {
+			closing = closedSquare
+		}
+		node, tokens, err := buildList(tokens, tok, closing)
+		if err != nil {
+			return nil, tokens, err
+		}
+		node.children = append([]*ASTNode{newValueNode(vector, tok.span)}, node.children...)
 		return node, tokens, nil
-	} else {
-		token, tokens = pop(tokens)
-		if token != openBracket {
-			return nil, tokens, errStr(openBracket, token)
+
+	default:
+		// TODO Check that this token is a value.
+		return newValueNode(tok.text, tok.span), tokens, nil
+	}
+}
+
+// This method reads the expressions after an opening bracket, up to the
+// closing one, and returns them as the children of a node.
+func buildList(tokens []token, opening token, closing string) (*ASTNode, []token, error) {
+	node := new(ASTNode)
+	node.isValue = false
+	// Create a slice with 0 length initially.
+	node.children = make([]*ASTNode, 0)
+
+	var err error
+	for {
+		tokens, err = skipDatumComments(tokens)
+		if err != nil {
+			return nil, tokens, err
+		}
+		if len(tokens) == 0 || tokens[0].text == closing {
+			break
+		}
+		var childNode *ASTNode = nil
+		childNode, tokens, err = buildAST(tokens)
+		if err != nil {
+			return nil, tokens, err
+		}
+		node.children = append(node.children, childNode)
+	}
+	if len(tokens) == 0 {
+		return nil, tokens, withSpan(errStr(closing, "nil"), opening.span)
+	}
+
+	var closingToken token
+	closingToken, tokens = pop(tokens)
+	node.span = joinSpans(opening.span, closingToken.span)
+	return node, tokens, nil
+}
+
+func newValueNode(value string, span Span) *ASTNode {
+	node := new(ASTNode)
+	node.isValue = true
+	node.value = value
+	node.children = nil
+	node.span = span
+	return node
+}
+
+// This method converts an AST into data, the way quote sees it. Names become
+// symbols, and lists become chains of pairs.
+func astToValue(env *LangEnv, node *ASTNode) (Value, error) {
+	if node.datum != nil {
+		return node.datum, nil
+	}
+	if node.isValue {
+		value, err := getValue(env, node.value)
+		if err != nil {
+			return nil, newSyntaxError("%s %s", errStr("value", node.value), err)
+		}
+		if value.getValueType() == varType {
+			var symbol symbolValue
+			return symbol.newValue(node.value), nil
+		}
+		return value, nil
+	}
+
+	children := node.children
+	// (a b . c) is a list whose last cdr is c, instead of the empty list.
+	var tail Value = emptyListValue{}
+	if n := len(children); n >= 3 && children[n-2].isValue && children[n-2].value == dot {
+		var err error
+		tail, err = astToValue(env, children[n-1])
+		if err != nil {
+			return nil, err
+		}
+		children = children[:n-2]
+	}
+
+	values := make([]Value, 0, len(children))
+	for _, child := range children {
+		value, err := astToValue(env, child)
+		if err != nil {
+			return nil, err
 		}
+		values = append(values, value)
+	}
+	for i := len(values) - 1; i >= 0; i-- {
+		tail = newPairValue(values[i], tail)
+	}
+	return tail, nil
+}
 
+// This method converts data back into an AST, so that it can be evaluated. It
+// is the reverse of astToValue: symbols become names again, and lists become
+// nodes with children. Any other value is kept as it is, in the datum of a
+// value node. All the nodes get the given span, as they were not read from a
+// source.
+func valueToAST(val Value, span Span) *ASTNode {
+	switch v := val.(type) {
+	case symbolValue:
+		return newValueNode(v.value, span)
+
+	case varValue:
+		// Operators, like the ones returned by defun, are referred to by name.
+		return newValueNode(v.varName, span)
+
+	case emptyListValue, pairValue:
 		node := new(ASTNode)
 		node.isValue = false
-		// Create a slice with 0 length initially.
 		node.children = make([]*ASTNode, 0)
-
-		tokensLen = len(tokens)
-		for len(tokens) != 0 && tokens[0] != closedBracket {
-			var childNode *ASTNode = nil
-			var err error = nil
-			// If this is not an open brace, this is a single value
-			if tokens[0] != openBracket {
-				token, tokens = pop(tokens)
-				childNode, _, err = buildAST([]string{token})
-			} else {
-				childNode, tokens, err = buildAST(tokens)
+		node.span = span
+		rest := val
+		for {
+			pair, ok := rest.(pairValue)
+			if !ok {
+				break
 			}
-			if err != nil {
-				return nil, tokens, err
-			}
-			node.children = append(node.children, childNode)
-		}
-		if len(tokens) == 0 {
-			return nil, tokens, errStr(closedBracket, "nil")
+			node.children = append(node.children, valueToAST(pair.car, span))
+			rest = pair.cdr
 		}
-
-		token, tokens = pop(tokens)
-		if token != closedBracket {
-			return nil, tokens, errStr(token, closedBracket)
+		if rest.getValueType() != emptyType {
+			node.children = append(node.children, newValueNode(dot, span), valueToAST(rest, span))
 		}
-		return node, tokens, nil
+		return node
 	}
+
+	node := newValueNode(val.Str(), span)
+	node.datum = val
+	return node
 }
 
 func StringifyAST(node *ASTNode) string {
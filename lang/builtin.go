@@ -4,6 +4,18 @@ package lang
 func builtinOperators() map[string]*Operator {
 	opMap := make(map[string]*Operator)
 	addBuiltinOperators(opMap)
+	addListOperators(opMap)
+	addNumberOperators(opMap)
+	addDecimalOperators(opMap)
+	addComplexOperators(opMap)
+	addMathOperators(opMap)
+	addStringOperators(opMap)
+	addCharOperators(opMap)
+	addHashMapOperators(opMap)
+	addVectorOperators(opMap)
+	addTypeOperators(opMap)
+	addMacroOperators(opMap)
+	addSyntaxOperators(opMap)
 	return opMap
 }
 
@@ -12,9 +24,13 @@ func builtinTypes() []Value {
 	// Append the values in the order of prefence, i.e, more specific types
 	// should be first.
 	types = append(types, new(stringValue))
+	types = append(types, new(charValue))
 	types = append(types, new(intValue))
 	types = append(types, new(bigIntValue))
+	types = append(types, new(decimalValue))
+	types = append(types, new(ratValue))
 	types = append(types, new(floatValue))
+	types = append(types, new(complexValue))
 	types = append(types, new(boolValue))
 	types = append(types, new(varValue))
 	return types
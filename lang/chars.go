@@ -0,0 +1,482 @@
+package lang
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	// Character operators
+	charToInteger  string = "char->integer"
+	integerToChar  string = "integer->char"
+	isAlphabetic   string = "char-alphabetic?"
+	isNumeric      string = "char-numeric?"
+	isWhitespace   string = "char-whitespace?"
+	isUpperCase    string = "char-upper-case?"
+	isLowerCase    string = "char-lower-case?"
+	charUpcase     string = "char-upcase"
+	charDowncase   string = "char-downcase"
+	charFoldcase   string = "char-foldcase"
+	stringRef      string = "string-ref"
+	stringToList   string = "string->list"
+	listToString   string = "list->string"
+	stringFoldcase string = "string-foldcase"
+
+	// Case-insensitive comparisons
+	stringCiEq  string = "string-ci=?"
+	stringCiLt  string = "string-ci<?"
+	stringCiGt  string = "string-ci>?"
+	stringCiLeq string = "string-ci<=?"
+	stringCiGeq string = "string-ci>=?"
+	charCiEq    string = "char-ci=?"
+	charCiLt    string = "char-ci<?"
+	charCiGt    string = "char-ci>?"
+	charCiLeq   string = "char-ci<=?"
+	charCiGeq   string = "char-ci>=?"
+)
+
+// The characters which are written by name, like #\space.
+var charNames = map[string]rune{
+	"alarm":     '\a',
+	"backspace": '\b',
+	"delete":    '\x7f',
+	"escape":    '\x1b',
+	"newline":   '\n',
+	"null":      0,
+	"return":    '\r',
+	"space":     ' ',
+	"tab":       '\t',
+}
+
+// The names of the characters in charNames, looked up by character.
+var charNamesByRune = charNameLookup(charNames)
+
+func charNameLookup(names map[string]rune) map[rune]string {
+	lookup := make(map[rune]string)
+	for name, r := range names {
+		lookup[r] = name
+	}
+	return lookup
+}
+
+// A charValue is a single Unicode code point, like #\a or #\λ.
+type charValue struct {
+	value rune
+}
+
+func newCharValue(r rune) charValue {
+	var val charValue
+	val.value = r
+	return val
+}
+
+func (v charValue) getValueType() valueType {
+	return charType
+}
+
+func (v charValue) to(targetType valueType) (Value, error) {
+	switch targetType {
+	case charType:
+		return v, nil
+	}
+	return nil, typeConvError(v.getValueType(), targetType)
+}
+
+// A character literal is #\ followed by the character, like #\a, by its name,
+// like #\space, or by its code point in hexadecimal, like #\x3bb.
+func (v charValue) ofType(targetValue string) bool {
+	return v.newValue(targetValue) != nil
+}
+
+// Characters are printed the way they would be read back. The ones which do
+// not show up when printed are written by name, or by code point.
+func (v charValue) Str() string {
+	if name, ok := charNamesByRune[v.value]; ok {
+		return charPrefix + name
+	}
+	if !unicode.IsGraphic(v.value) {
+		return charPrefix + "x" + strconv.FormatInt(int64(v.value), 16)
+	}
+	return charPrefix + string(v.value)
+}
+
+func (v charValue) newValue(str string) Value {
+	if !strings.HasPrefix(str, charPrefix) {
+		return nil
+	}
+	rest := []rune(str[len(charPrefix):])
+	if len(rest) == 1 {
+		return newCharValue(rest[0])
+	}
+	if r, ok := charNames[string(rest)]; ok {
+		return newCharValue(r)
+	}
+	if len(rest) > 1 && rest[0] == 'x' {
+		code, err := strconv.ParseUint(string(rest[1:]), 16, 32)
+		if err == nil && isCodePoint(int64(code)) {
+			return newCharValue(rune(code))
+		}
+	}
+	return nil
+}
+
+// Returns true if n is a Unicode code point which can be a character, which
+// leaves out the surrogate halves.
+func isCodePoint(n int64) bool {
+	return n >= 0 && n <= unicode.MaxRune && (n < 0xd800 || n > 0xdfff)
+}
+
+// Returns the character with its case folded, so that characters which only
+// differ in case are the same after it.
+func foldRune(r rune) rune {
+	return unicode.ToLower(unicode.ToUpper(r))
+}
+
+// The characters which fold to more than one character in a string, like ß,
+// which is the same as ss once the case is folded.
+var multiRuneFolds = map[rune]string{
+	'ß': "ss",
+	'ẞ': "ss",
+	'ŉ': "ʼn",
+	'ﬀ': "ff",
+	'ﬁ': "fi",
+	'ﬂ': "fl",
+	'ﬃ': "ffi",
+	'ﬄ': "ffl",
+	'ﬅ': "st",
+	'ﬆ': "st",
+}
+
+// Returns the string with the case of every character folded.
+func foldString(s string) string {
+	var buffer bytes.Buffer
+	for _, r := range s {
+		if fold, ok := multiRuneFolds[r]; ok {
+			buffer.WriteString(fold)
+		} else {
+			buffer.WriteRune(foldRune(r))
+		}
+	}
+	return buffer.String()
+}
+
+// Returns the string in upper case. The characters which fold to more than
+// one character are changed to those characters in upper case, so "straße"
+// becomes "STRASSE", which is what it folds to as well.
+func upcaseString(s string) string {
+	var buffer bytes.Buffer
+	for _, r := range s {
+		if fold, ok := multiRuneFolds[r]; ok && unicode.IsLower(r) {
+			buffer.WriteString(strings.ToUpper(fold))
+		} else {
+			buffer.WriteRune(unicode.ToUpper(r))
+		}
+	}
+	return buffer.String()
+}
+
+//go:generate go run gen_accents.go -data UnicodeData.txt -o accents_table.go
+
+// Returns the string with its case folded, and the accents taken off its
+// letters. Letters are looked up in accentBases, which comes from their
+// decompositions in Unicode, and the combining marks left over, like those of
+// letters which were written decomposed, are dropped.
+func stripAccents(s string) string {
+	var buffer bytes.Buffer
+	for _, r := range s {
+		if base, ok := accentBases[r]; ok {
+			r = base
+		}
+		if !unicode.Is(unicode.Mn, r) {
+			buffer.WriteRune(r)
+		}
+	}
+	return foldString(buffer.String())
+}
+
+// This method orders strings the way they are ordered in a dictionary: by
+// their letters, ignoring case and accents, so "apple" comes before "Banana",
+// and "é" before "f". Strings which are the same but for their accents are
+// then ordered ignoring case, and strings which only differ in case are
+// ordered by their code points, so that only equal strings compare as equal.
+func collateStrings(a, b string) int {
+	if c := strings.Compare(stripAccents(a), stripAccents(b)); c != 0 {
+		return c
+	}
+	if c := strings.Compare(foldString(a), foldString(b)); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+// Returns the runes of the char operands, after checking that they are all
+// characters.
+func charArgs(operator string, operands []Atom) ([]rune, error) {
+	if _, err := checkArgTypes(operator, &operands, []valueType{charType}); err != nil {
+		return nil, err
+	}
+	runes := make([]rune, 0, len(operands))
+	for _, o := range operands {
+		runes = append(runes, o.Val.(charValue).value)
+	}
+	return runes, nil
+}
+
+func addCharOperators(opMap map[string]*Operator) {
+	addOperator(opMap,
+		&Operator{
+			symbol:      charToInteger,
+			minArgCount: 1,
+			maxArgCount: 1,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				runes, err := charArgs(charToInteger, operands)
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				var val intValue
+				val.value = int64(runes[0])
+				retVal.Val = val
+				return retVal
+			},
+		},
+	)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      integerToChar,
+			minArgCount: 1,
+			maxArgCount: 1,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				n, err := intArg(integerToChar, operands[0])
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				if !isCodePoint(n) {
+					retVal.Err = newEvalError("%d is not a Unicode code point.", n)
+					return retVal
+				}
+				retVal.Val = newCharValue(rune(n))
+				return retVal
+			},
+		},
+	)
+
+	predicates := map[string]func(rune) bool{
+		isAlphabetic: unicode.IsLetter,
+		isNumeric:    unicode.IsDigit,
+		isWhitespace: unicode.IsSpace,
+		isUpperCase:  unicode.IsUpper,
+		isLowerCase:  unicode.IsLower,
+	}
+	for _, symbol := range []string{isAlphabetic, isNumeric, isWhitespace, isUpperCase, isLowerCase} {
+		predSymbol := symbol
+		holds := predicates[predSymbol]
+		addOperator(opMap,
+			&Operator{
+				symbol:      predSymbol,
+				minArgCount: 1,
+				maxArgCount: 1,
+				handler: func(env *LangEnv, operands []Atom) Atom {
+					var retVal Atom
+					runes, err := charArgs(predSymbol, operands)
+					if err != nil {
+						retVal.Err = err
+						return retVal
+					}
+					retVal.Val = newBoolValue(holds(runes[0]))
+					return retVal
+				},
+			},
+		)
+	}
+
+	conversions := map[string]func(rune) rune{
+		charUpcase:   unicode.ToUpper,
+		charDowncase: unicode.ToLower,
+		charFoldcase: foldRune,
+	}
+	for _, symbol := range []string{charUpcase, charDowncase, charFoldcase} {
+		convSymbol := symbol
+		convert := conversions[convSymbol]
+		addOperator(opMap,
+			&Operator{
+				symbol:      convSymbol,
+				minArgCount: 1,
+				maxArgCount: 1,
+				handler: func(env *LangEnv, operands []Atom) Atom {
+					var retVal Atom
+					runes, err := charArgs(convSymbol, operands)
+					if err != nil {
+						retVal.Err = err
+						return retVal
+					}
+					retVal.Val = newCharValue(convert(runes[0]))
+					return retVal
+				},
+			},
+		)
+	}
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      stringFoldcase,
+			minArgCount: 1,
+			maxArgCount: 1,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				strs, err := stringArgs(stringFoldcase, operands)
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				retVal.Val = newStringValue(foldString(strs[0]))
+				return retVal
+			},
+		},
+	)
+
+	// (string-ref s i) is the character at position i in s, counting from 0.
+	addOperator(opMap,
+		&Operator{
+			symbol:      stringRef,
+			minArgCount: 2,
+			maxArgCount: 2,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				strs, err := stringArgs(stringRef, operands[:1])
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				i, err := intArg(stringRef, operands[1])
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				runes := []rune(strs[0])
+				if i < 0 || i >= int64(len(runes)) {
+					retVal.Err = newEvalError("Cannot take the character at %d of %s, which has %d.",
+						i, operands[0].Val.Str(), len(runes))
+					return retVal
+				}
+				retVal.Val = newCharValue(runes[i])
+				return retVal
+			},
+		},
+	)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      stringToList,
+			minArgCount: 1,
+			maxArgCount: 1,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				strs, err := stringArgs(stringToList, operands)
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				chars := make([]Value, 0, len(strs[0]))
+				for _, r := range strs[0] {
+					chars = append(chars, newCharValue(r))
+				}
+				retVal.Val = newList(chars)
+				return retVal
+			},
+		},
+	)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      listToString,
+			minArgCount: 1,
+			maxArgCount: 1,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				values, err := listToSlice(operands[0].Val)
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				chars := make([]Atom, 0, len(values))
+				for _, v := range values {
+					var o Atom
+					o.Val = v
+					chars = append(chars, o)
+				}
+				runes, err := charArgs(listToString, chars)
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				retVal.Val = newStringValue(string(runes))
+				return retVal
+			},
+		},
+	)
+
+	// The case-insensitive comparisons are chained like < and the others, and
+	// compare strings or characters after folding their case.
+	ciComparisons := map[string]func(int) bool{
+		stringCiEq:  func(c int) bool { return c == 0 },
+		stringCiLt:  func(c int) bool { return c < 0 },
+		stringCiGt:  func(c int) bool { return c > 0 },
+		stringCiLeq: func(c int) bool { return c <= 0 },
+		stringCiGeq: func(c int) bool { return c >= 0 },
+		charCiEq:    func(c int) bool { return c == 0 },
+		charCiLt:    func(c int) bool { return c < 0 },
+		charCiGt:    func(c int) bool { return c > 0 },
+		charCiLeq:   func(c int) bool { return c <= 0 },
+		charCiGeq:   func(c int) bool { return c >= 0 },
+	}
+	for _, symbol := range []string{stringCiEq, stringCiLt, stringCiGt, stringCiLeq, stringCiGeq,
+		charCiEq, charCiLt, charCiGt, charCiLeq, charCiGeq} {
+		cmpSymbol := symbol
+		holds := ciComparisons[cmpSymbol]
+		addOperator(opMap,
+			&Operator{
+				symbol:      cmpSymbol,
+				minArgCount: 2,
+				maxArgCount: math.MaxInt32,
+				handler: func(env *LangEnv, operands []Atom) Atom {
+					var retVal Atom
+					folded := make([]string, 0, len(operands))
+					if strings.HasPrefix(cmpSymbol, "char") {
+						runes, err := charArgs(cmpSymbol, operands)
+						if err != nil {
+							retVal.Err = err
+							return retVal
+						}
+						for _, r := range runes {
+							folded = append(folded, string(foldRune(r)))
+						}
+					} else {
+						strs, err := stringArgs(cmpSymbol, operands)
+						if err != nil {
+							retVal.Err = err
+							return retVal
+						}
+						for _, str := range strs {
+							folded = append(folded, foldString(str))
+						}
+					}
+
+					result := true
+					for i := 1; i < len(folded) && result; i++ {
+						result = holds(strings.Compare(folded[i-1], folded[i]))
+					}
+					retVal.Val = newBoolValue(result)
+					return retVal
+				},
+			},
+		)
+	}
+}
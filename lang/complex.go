@@ -0,0 +1,244 @@
+package lang
+
+import (
+	"math/cmplx"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Complex number operators
+	realPart        string = "real-part"
+	imagPart        string = "imag-part"
+	magnitude       string = "magnitude"
+	angle           string = "angle"
+	makeRectangular string = "make-rectangular"
+	makePolar       string = "make-polar"
+)
+
+// A complexValue is a complex number with floating point parts, like 3+4i.
+type complexValue struct {
+	value complex128
+}
+
+func newComplexValue(c complex128) complexValue {
+	var val complexValue
+	val.value = c
+	return val
+}
+
+func (v complexValue) getValueType() valueType {
+	return complexType
+}
+
+func (v complexValue) to(targetType valueType) (Value, error) {
+	switch targetType {
+	case complexType:
+		return v, nil
+	}
+	return nil, typeConvError(v.getValueType(), targetType)
+}
+
+// A complex literal is a real and an imaginary part, like 3+4i or 1.5-2i, or
+// just the imaginary part, like 4i. An imaginary part of one can be written
+// without the one, as in 1+i, 1-i, +i and -i.
+func (v complexValue) ofType(targetValue string) bool {
+	return v.newValue(targetValue) != nil
+}
+
+func (v complexValue) Str() string {
+	imagStr := strconv.FormatFloat(imag(v.value), 'g', -1, 64)
+	if !strings.HasPrefix(imagStr, "-") && !strings.HasPrefix(imagStr, "+") {
+		imagStr = "+" + imagStr
+	}
+	return strconv.FormatFloat(real(v.value), 'g', -1, 64) + imagStr + "i"
+}
+
+func (v complexValue) newValue(str string) Value {
+	if strings.HasSuffix(str, "+i") || strings.HasSuffix(str, "-i") {
+		return unitImagValue(str)
+	}
+	// Names like i or infi are not numbers, so there has to be a digit.
+	if !strings.HasSuffix(str, "i") || !strings.ContainsAny(str, "0123456789") ||
+		strings.HasPrefix(str, "(") {
+		return nil
+	}
+	c, err := strconv.ParseComplex(str, 128)
+	if err != nil {
+		return nil
+	}
+	return newComplexValue(c)
+}
+
+// Returns the value of a complex literal whose imaginary part is written as
+// just its sign, like 1+i or -i, or nil if the rest is not a real number.
+func unitImagValue(str string) Value {
+	realStr, imagSign := str[:len(str)-2], str[len(str)-2]
+	imagVal := 1.0
+	if imagSign == '-' {
+		imagVal = -1
+	}
+	if len(realStr) == 0 {
+		return newComplexValue(complex(0, imagVal))
+	}
+	// Like in the other literals, names like inf+i are not numbers.
+	if !strings.ContainsAny(realStr, "0123456789") {
+		return nil
+	}
+	r, err := strconv.ParseFloat(realStr, 64)
+	if err != nil {
+		return nil
+	}
+	return newComplexValue(complex(r, imagVal))
+}
+
+// Returns a real number as a complex number, with no imaginary part.
+func realToComplex(v Value) (Value, error) {
+	f, err := v.to(floatType)
+	if err != nil {
+		return nil, err
+	}
+	return newComplexValue(complex(f.(floatValue).value, 0)), nil
+}
+
+// Returns the value of a number as a complex128.
+func toComplex(v Value) (complex128, error) {
+	c, err := v.to(complexType)
+	if err != nil {
+		return 0, err
+	}
+	return c.(complexValue).value, nil
+}
+
+func addComplexOperators(opMap map[string]*Operator) {
+	realTypes := []valueType{intType, bigIntType, decimalType, ratType, floatType}
+	numTypes := append(realTypes, complexType)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      realPart,
+			minArgCount: 1,
+			maxArgCount: 1,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				_, retVal.Err = checkArgTypes(realPart, &operands, numTypes)
+				if retVal.Err != nil {
+					return retVal
+				}
+				retVal.Val = operands[0].Val
+				if c, ok := operands[0].Val.(complexValue); ok {
+					var val floatValue
+					val.value = real(c.value)
+					retVal.Val = val
+				}
+				return retVal
+			},
+		},
+	)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      imagPart,
+			minArgCount: 1,
+			maxArgCount: 1,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				_, retVal.Err = checkArgTypes(imagPart, &operands, numTypes)
+				if retVal.Err != nil {
+					return retVal
+				}
+				// Real numbers have no imaginary part.
+				var zero intValue
+				retVal.Val = zero
+				if c, ok := operands[0].Val.(complexValue); ok {
+					var val floatValue
+					val.value = imag(c.value)
+					retVal.Val = val
+				}
+				return retVal
+			},
+		},
+	)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      magnitude,
+			minArgCount: 1,
+			maxArgCount: 1,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				_, retVal.Err = checkArgTypes(magnitude, &operands, numTypes)
+				if retVal.Err != nil {
+					return retVal
+				}
+				if c, ok := operands[0].Val.(complexValue); ok {
+					var val floatValue
+					val.value = cmplx.Abs(c.value)
+					retVal.Val = val
+					return retVal
+				}
+				retVal.Val = absValue(operands[0].Val)
+				return retVal
+			},
+		},
+	)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      angle,
+			minArgCount: 1,
+			maxArgCount: 1,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				_, retVal.Err = checkArgTypes(angle, &operands, numTypes)
+				if retVal.Err != nil {
+					return retVal
+				}
+				c, err := toComplex(operands[0].Val)
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				var val floatValue
+				val.value = cmplx.Phase(c)
+				retVal.Val = val
+				return retVal
+			},
+		},
+	)
+
+	// (make-rectangular x y) is x+yi, and (make-polar m a) is the number with
+	// the magnitude m, at the angle a.
+	for _, symbol := range []string{makeRectangular, makePolar} {
+		makeSymbol := symbol
+		addOperator(opMap,
+			&Operator{
+				symbol:      makeSymbol,
+				minArgCount: 2,
+				maxArgCount: 2,
+				handler: func(env *LangEnv, operands []Atom) Atom {
+					var retVal Atom
+					_, retVal.Err = checkArgTypes(makeSymbol, &operands, realTypes)
+					if retVal.Err != nil {
+						return retVal
+					}
+					parts := make([]float64, 0, 2)
+					for _, o := range operands {
+						f, err := o.Val.to(floatType)
+						if err != nil {
+							retVal.Err = err
+							return retVal
+						}
+						parts = append(parts, f.(floatValue).value)
+					}
+					if makeSymbol == makePolar {
+						retVal.Val = newComplexValue(cmplx.Rect(parts[0], parts[1]))
+					} else {
+						retVal.Val = newComplexValue(complex(parts[0], parts[1]))
+					}
+					return retVal
+				},
+			},
+		)
+	}
+}
@@ -0,0 +1,494 @@
+package lang
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Decimal operators
+	decimalPrecision    string = "decimal-precision"
+	setDecimalPrecision string = "set-decimal-precision!"
+	decimalRounding     string = "decimal-rounding"
+	setDecimalRounding  string = "set-decimal-rounding!"
+	decimalRound        string = "decimal-round"
+
+	// Decimal literals end with this, like 0.1m.
+	decimalSuffix string = "m"
+)
+
+const (
+	// Rounding modes
+	roundHalfEven string = "half-even"
+	roundHalfUp   string = "half-up"
+	roundHalfDown string = "half-down"
+	roundUp       string = "up"
+	roundDown     string = "down"
+	roundCeiling  string = "ceiling"
+	roundFloor    string = "floor"
+)
+
+var roundingModes = []string{roundHalfEven, roundHalfUp, roundHalfDown, roundUp, roundDown, roundCeiling, roundFloor}
+
+// The number of significant digits decimals are rounded to, unless it is
+// changed with set-decimal-precision!.
+const defaultDecimalPrecision = 28
+
+// The most significant digits decimals can be rounded to. Dividing to more
+// digits than this would take too long.
+const maxDecimalPrecision = 100000
+
+// The largest exponent a decimal literal can have, like the 3 in 1e3m. The
+// digits of larger ones would take too long to print.
+const maxDecimalExponent = 1 << 16
+
+// A decimalContext says how the results of decimal arithmetic are rounded: to
+// at most precision significant digits, using the given rounding mode.
+type decimalContext struct {
+	precision int
+	rounding  string
+}
+
+// A decimalValue is an exact base 10 number, like 0.1m. Its value is
+// unscaled * 10^-scale, so 1.50m has an unscaled value of 150 and a scale of
+// 2. The scale is negative for large numbers which were rounded to fewer
+// digits than they have before the point.
+type decimalValue struct {
+	unscaled *big.Int
+	scale    int
+}
+
+// Zero is always kept with a scale of 0, so that every zero is 0m, however
+// it was computed.
+func newDecimalValue(unscaled *big.Int, scale int) decimalValue {
+	var val decimalValue
+	val.unscaled = unscaled
+	val.scale = scale
+	if unscaled.Sign() == 0 {
+		val.scale = 0
+	}
+	return val
+}
+
+func (v decimalValue) getValueType() valueType {
+	return decimalType
+}
+
+func (v decimalValue) to(targetType valueType) (Value, error) {
+	switch targetType {
+	case decimalType:
+		return v, nil
+	case ratType:
+		var val ratValue
+		val.value = v.rat()
+		return val, nil
+	case floatType:
+		var val floatValue
+		val.value, _ = strconv.ParseFloat(strings.TrimSuffix(v.Str(), decimalSuffix), 64)
+		return val, nil
+	case complexType:
+		return realToComplex(v)
+	}
+	return nil, typeConvError(v.getValueType(), targetType)
+}
+
+// A decimal literal is a number with an optional fraction and exponent,
+// followed by an m, like 12m, -0.5m, 1.50m or 1.5e-3m.
+func (v decimalValue) ofType(targetValue string) bool {
+	return v.newValue(targetValue) != nil
+}
+
+func (v decimalValue) Str() string {
+	digits := new(big.Int).Abs(v.unscaled).String()
+	if v.unscaled.Sign() == 0 {
+		return "0" + decimalSuffix
+	}
+	if v.scale < 0 {
+		digits += strings.Repeat("0", -v.scale)
+	} else if v.scale > 0 {
+		if len(digits) <= v.scale {
+			digits = strings.Repeat("0", v.scale-len(digits)+1) + digits
+		}
+		point := len(digits) - v.scale
+		digits = digits[:point] + "." + digits[point:]
+	}
+	if v.unscaled.Sign() < 0 {
+		digits = "-" + digits
+	}
+	return digits + decimalSuffix
+}
+
+func (v decimalValue) newValue(str string) Value {
+	if !strings.HasSuffix(str, decimalSuffix) {
+		return nil
+	}
+	number := strings.TrimSuffix(str, decimalSuffix)
+	exponent := 0
+	if i := strings.IndexAny(number, "eE"); i >= 0 {
+		exp, err := strconv.Atoi(number[i+1:])
+		if err != nil || exp < -maxDecimalExponent || exp > maxDecimalExponent {
+			return nil
+		}
+		number, exponent = number[:i], exp
+	}
+	sign := ""
+	if strings.HasPrefix(number, "+") || strings.HasPrefix(number, "-") {
+		sign, number = number[:1], number[1:]
+	}
+	intPart, fracPart := number, ""
+	if i := strings.Index(number, "."); i >= 0 {
+		intPart, fracPart = number[:i], number[i+1:]
+	}
+	if len(intPart)+len(fracPart) == 0 || !isDigits(intPart) || !isDigits(fracPart) {
+		return nil
+	}
+	unscaled, ok := new(big.Int).SetString(sign+intPart+fracPart, 10)
+	if !ok {
+		return nil
+	}
+	return newDecimalValue(unscaled, len(fracPart)-exponent)
+}
+
+func isDigits(str string) bool {
+	for _, r := range str {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns 10^n, for n >= 0.
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// Returns the number of digits in the integer, ignoring its sign.
+func numDigits(n *big.Int) int {
+	if n.Sign() == 0 {
+		return 1
+	}
+	return len(new(big.Int).Abs(n).String())
+}
+
+// Returns the exact value of the decimal, as a fraction.
+func (v decimalValue) rat() *big.Rat {
+	if v.scale < 0 {
+		return new(big.Rat).SetInt(new(big.Int).Mul(v.unscaled, pow10(-v.scale)))
+	}
+	return new(big.Rat).SetFrac(v.unscaled, pow10(v.scale))
+}
+
+// Returns the unscaled values of both the decimals, at the larger of their
+// scales, along with that scale.
+func alignDecimals(a, b decimalValue) (*big.Int, *big.Int, int) {
+	if a.scale < b.scale {
+		return new(big.Int).Mul(a.unscaled, pow10(b.scale-a.scale)), b.unscaled, b.scale
+	}
+	return a.unscaled, new(big.Int).Mul(b.unscaled, pow10(a.scale-b.scale)), a.scale
+}
+
+// Sums, differences and products of decimals are exact. It is up to the
+// caller to round them with a decimalContext.
+func (v decimalValue) add(o decimalValue) decimalValue {
+	a, b, scale := alignDecimals(v, o)
+	return newDecimalValue(new(big.Int).Add(a, b), scale)
+}
+
+func (v decimalValue) sub(o decimalValue) decimalValue {
+	a, b, scale := alignDecimals(v, o)
+	return newDecimalValue(new(big.Int).Sub(a, b), scale)
+}
+
+func (v decimalValue) mul(o decimalValue) decimalValue {
+	return newDecimalValue(new(big.Int).Mul(v.unscaled, o.unscaled), v.scale+o.scale)
+}
+
+func (v decimalValue) cmp(o decimalValue) int {
+	a, b, _ := alignDecimals(v, o)
+	return a.Cmp(b)
+}
+
+// This method drops the zeros at the end of the decimal, like 1.500 to 1.5,
+// but keeps at least minScale digits after the point.
+func (v decimalValue) reduce(minScale int) decimalValue {
+	ten := big.NewInt(10)
+	for v.scale > minScale {
+		q, m := new(big.Int).QuoRem(v.unscaled, ten, new(big.Int))
+		if m.Sign() != 0 {
+			break
+		}
+		v = newDecimalValue(q, v.scale-1)
+	}
+	return v
+}
+
+// This method divides num by den, which has to be positive, and rounds the
+// quotient to an integer using the given rounding mode.
+func roundQuotient(num, den *big.Int, mode string) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	negative := num.Sign() < 0
+	// Compare the remainder to half of den.
+	half := new(big.Int).Lsh(r.Abs(r), 1).Cmp(den)
+
+	var awayFromZero bool
+	switch mode {
+	case roundUp:
+		awayFromZero = true
+	case roundDown:
+		awayFromZero = false
+	case roundCeiling:
+		awayFromZero = !negative
+	case roundFloor:
+		awayFromZero = negative
+	case roundHalfUp:
+		awayFromZero = half >= 0
+	case roundHalfDown:
+		awayFromZero = half > 0
+	default:
+		awayFromZero = half > 0 || (half == 0 && q.Bit(0) == 1)
+	}
+
+	if awayFromZero {
+		if negative {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+// Returns the fraction as a decimal with the given scale, rounded using the
+// rounding mode of the context.
+func (c decimalContext) rescale(r *big.Rat, scale int) decimalValue {
+	num := new(big.Int).Set(r.Num())
+	den := new(big.Int).Set(r.Denom())
+	if scale >= 0 {
+		num.Mul(num, pow10(scale))
+	} else {
+		den.Mul(den, pow10(-scale))
+	}
+	return newDecimalValue(roundQuotient(num, den, c.rounding), scale)
+}
+
+// This method rounds the decimal to the precision of the context. Rounding
+// can carry into a new digit, like 9.99 to 10.0, so it is repeated until the
+// number fits.
+func (c decimalContext) round(v decimalValue) decimalValue {
+	for extra := numDigits(v.unscaled) - c.precision; extra > 0; extra = numDigits(v.unscaled) - c.precision {
+		v = c.rescale(v.rat(), v.scale-extra)
+	}
+	return v
+}
+
+// This method converts the fraction to a decimal, rounded to the precision of
+// the context. If the decimal is exact, it is given as few digits after the
+// point as it needs, but no fewer than idealScale.
+func (c decimalContext) fromRat(r *big.Rat, idealScale int) decimalValue {
+	if r.Sign() == 0 {
+		return newDecimalValue(new(big.Int), idealScale)
+	}
+
+	// Find the number of digits r has before the point. If it is less than
+	// one, this is zero or negative, for the zeros after the point.
+	num := new(big.Int).Abs(r.Num())
+	den := r.Denom()
+	var intDigits int
+	if num.Cmp(den) >= 0 {
+		intDigits = numDigits(new(big.Int).Quo(num, den))
+	} else {
+		zeros := numDigits(den) - numDigits(num)
+		if new(big.Int).Mul(num, pow10(zeros)).Cmp(den) < 0 {
+			zeros++
+		}
+		intDigits = 1 - zeros
+	}
+
+	v := c.rescale(r, c.precision-intDigits)
+	if v.rat().Cmp(r) != 0 {
+		return c.round(v)
+	}
+	return v.reduce(idealScale)
+}
+
+// This method returns the square root of a decimal, which must not be
+// negative, rounded to the precision of the context.
+func (c decimalContext) sqrt(v decimalValue) decimalValue {
+	idealScale := (v.scale + 1) / 2
+	if v.scale < 0 {
+		idealScale = v.scale / 2
+	}
+	// Work out the root with at least two more digits than the precision, as
+	// a whole number, so that it only needs to be rounded once.
+	scale := c.precision + idealScale + 1
+	n := new(big.Int).Mul(v.unscaled, pow10(2*scale-v.scale))
+	root, exact := isqrt(n)
+	if !exact {
+		// The root is irrational, so it is never exactly halfway between
+		// two decimals. A digit at the end makes sure it is not rounded as if
+		// it were.
+		root.Mul(root, big.NewInt(10)).Add(root, big.NewInt(1))
+		return c.round(newDecimalValue(root, scale+1))
+	}
+	return c.round(newDecimalValue(root, scale).reduce(idealScale))
+}
+
+// This method divides two decimals, rounding the quotient to the precision of
+// the context. The divisor must not be zero.
+func (c decimalContext) quo(a, b decimalValue) decimalValue {
+	return c.fromRat(new(big.Rat).Quo(a.rat(), b.rat()), a.scale-b.scale)
+}
+
+// Returns the number as a decimal. Floats are converted using their shortest
+// representation, so 0.1 becomes 0.1m, instead of its exact binary value.
+func toDecimal(c decimalContext, v Value) (decimalValue, error) {
+	switch val := v.(type) {
+	case intValue:
+		return newDecimalValue(big.NewInt(val.value), 0), nil
+	case bigIntValue:
+		return newDecimalValue(new(big.Int).Set(val.value), 0), nil
+	case decimalValue:
+		return val, nil
+	case ratValue:
+		return c.fromRat(val.value, 0), nil
+	case floatValue:
+		if math.IsInf(val.value, 0) || math.IsNaN(val.value) {
+			return decimalValue{}, newTypeError("", []Value{v}, "%s has no decimal value.", v.Str())
+		}
+		var d decimalValue
+		str := strconv.FormatFloat(val.value, 'f', -1, 64) + decimalSuffix
+		return d.newValue(str).(decimalValue), nil
+	}
+	return decimalValue{}, typeConvError(v.getValueType(), decimalType)
+}
+
+func addDecimalOperators(opMap map[string]*Operator) {
+	addOperator(opMap,
+		&Operator{
+			symbol:      decimalPrecision,
+			minArgCount: 0,
+			maxArgCount: 0,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				var val intValue
+				val.value = int64(env.global().decimals.precision)
+				retVal.Val = val
+				return retVal
+			},
+		},
+	)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      setDecimalPrecision,
+			minArgCount: 1,
+			maxArgCount: 1,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				_, retVal.Err = checkArgTypes(setDecimalPrecision, &operands, []valueType{intType})
+				if retVal.Err != nil {
+					return retVal
+				}
+				precision := operands[0].Val.(intValue)
+				if precision.value < 1 || precision.value > maxDecimalPrecision {
+					retVal.Err = newTypeError(setDecimalPrecision, []Value{precision},
+						"Decimal precision has to be from 1 to %d digits, got %s.", maxDecimalPrecision, precision.Str())
+					return retVal
+				}
+				env.global().decimals.precision = int(precision.value)
+				retVal.Val = precision
+				return retVal
+			},
+		},
+	)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      decimalRounding,
+			minArgCount: 0,
+			maxArgCount: 0,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				retVal.Val = newSymbolValue(env.global().decimals.rounding)
+				return retVal
+			},
+		},
+	)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      setDecimalRounding,
+			minArgCount: 1,
+			maxArgCount: 1,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				_, retVal.Err = checkArgTypes(setDecimalRounding, &operands, []valueType{stringType, symbolType})
+				if retVal.Err != nil {
+					return retVal
+				}
+				mode := operands[0].Val.Str()
+				if str, ok := operands[0].Val.(stringValue); ok {
+					mode = str.value
+				}
+				for _, m := range roundingModes {
+					if m == mode {
+						env.global().decimals.rounding = mode
+						retVal.Val = newSymbolValue(mode)
+						return retVal
+					}
+				}
+				retVal.Err = newTypeError(setDecimalRounding, []Value{operands[0].Val},
+					"Unknown rounding mode %s, expected one of: %s.", mode, strings.Join(roundingModes, ", "))
+				return retVal
+			},
+		},
+	)
+
+	// (decimal-round x places) rounds x to a decimal with the given number of
+	// digits after the point, which is 0 if it is left out.
+	addOperator(opMap,
+		&Operator{
+			symbol:      decimalRound,
+			minArgCount: 1,
+			maxArgCount: 2,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				number, placesArg := operands[:1], operands[1:]
+				_, retVal.Err = checkArgTypes(decimalRound, &number,
+					[]valueType{intType, bigIntType, decimalType, ratType, floatType})
+				if retVal.Err != nil {
+					return retVal
+				}
+				_, retVal.Err = checkArgTypes(decimalRound, &placesArg, []valueType{intType})
+				if retVal.Err != nil {
+					return retVal
+				}
+				places := 0
+				if len(operands) == 2 {
+					places = int(operands[1].Val.(intValue).value)
+				}
+
+				context := env.global().decimals
+				var r *big.Rat
+				if ratVal, ok := operands[0].Val.(ratValue); ok {
+					r = ratVal.value
+				} else {
+					d, err := toDecimal(context, operands[0].Val)
+					if err != nil {
+						retVal.Err = err
+						return retVal
+					}
+					r = d.rat()
+				}
+				retVal.Val = context.rescale(r, places)
+				return retVal
+			},
+		},
+	)
+}
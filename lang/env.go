@@ -1,12 +1,39 @@
 package lang
 
+import (
+	"io"
+	"os"
+)
+
 // Data required for interpretation of the language.
 // We start with the default environment, and build on top of it, over time.
+//
+// An environment is a chain of frames. The global frame holds the builtin
+// operators, and every method call or local binding adds a frame on top of
+// the one it was defined in. Names are looked up from the innermost frame
+// outwards.
 type LangEnv struct {
-	opMap          map[string]*Operator
-	types          []Value
-	varMap         map[string]Value
+	parent *LangEnv
+	opMap  map[string]*Operator
+	types  []Value
+	varMap map[string]Value
+	// Only set in the frames which syntax-rules expansions are evaluated in.
+	aliases map[string]syntaxAlias
+	// Only tracked in the global frame.
 	recursionDepth int
+	gensymCount    int
+	lambdaCount    int
+	decimals       decimalContext
+	output         io.Writer
+}
+
+// A syntaxAlias is a name which a syntax-rules template introduced. It was
+// renamed, so that it can not clash with the names in the code around it, and
+// refers to the original name in the environment the macro was defined in,
+// unless something binds the alias itself.
+type syntaxAlias struct {
+	name string
+	env  *LangEnv
 }
 
 func NewEnv() *LangEnv {
@@ -17,17 +44,143 @@ func NewEnv() *LangEnv {
 
 // Initialize the environment
 func (e *LangEnv) Init() {
-	// e.opMap = make(map[string]*Operator)
+	e.parent = nil
 	e.opMap = builtinOperators()
 	e.types = builtinTypes()
 	e.varMap = make(map[string]Value)
 	e.recursionDepth = 0
+	e.gensymCount = 0
+	e.lambdaCount = 0
+	e.decimals = decimalContext{defaultDecimalPrecision, roundHalfEven}
+	e.output = os.Stdout
+}
+
+// Sets where display, write and newline print to. It is os.Stdout, unless it
+// is changed.
+func (e *LangEnv) SetOutput(w io.Writer) {
+	e.global().output = w
+}
+
+// Creates a new, empty frame on top of the given environment. Names bound in
+// the new frame shadow the ones in the parent, and are not visible there.
+func newChildEnv(parent *LangEnv) *LangEnv {
+	env := new(LangEnv)
+	env.parent = parent
+	env.opMap = make(map[string]*Operator)
+	env.varMap = make(map[string]Value)
+	env.types = parent.types
+	return env
+}
+
+// Creates the frame a syntax-rules expansion is evaluated in, which knows what
+// the names renamed by the expansion stand for. It binds nothing itself, so
+// definitions made by the expansion go to the frame around it, and it goes
+// away along with the expansion.
+func newExpansionEnv(parent *LangEnv, aliases map[string]syntaxAlias) *LangEnv {
+	env := newChildEnv(parent)
+	env.aliases = aliases
+	return env
+}
+
+// Returns the frame definitions like defvar and defun bind names in. It is
+// this frame, unless this is the frame of an expansion.
+func (e *LangEnv) definitionFrame() *LangEnv {
+	frame := e
+	for frame.aliases != nil && frame.parent != nil {
+		frame = frame.parent
+	}
+	return frame
+}
+
+// Returns what sym stands for, if an expansion around this frame renamed it.
+func (e *LangEnv) lookupAlias(sym string) (syntaxAlias, bool) {
+	if !isFreshName(sym) {
+		return syntaxAlias{}, false
+	}
+	for frame := e; frame != nil; frame = frame.parent {
+		if alias, ok := frame.aliases[sym]; ok {
+			return alias, true
+		}
+	}
+	return syntaxAlias{}, false
+}
+
+// Returns the global frame, which all other frames are built on.
+func (e *LangEnv) global() *LangEnv {
+	frame := e
+	for frame.parent != nil {
+		frame = frame.parent
+	}
+	return frame
+}
+
+// Returns the nearest frame which binds sym, either to an operator or to a
+// variable, along with the name it is bound to there. The name differs from
+// sym if sym is an alias, which is not bound itself. The frame is nil if sym
+// is not bound at all.
+func (e *LangEnv) bindingFrame(sym string) (*LangEnv, string) {
+	for frame := e; frame != nil; frame = frame.parent {
+		_, isOp := frame.opMap[sym]
+		_, isVar := frame.varMap[sym]
+		if isOp || isVar {
+			return frame, sym
+		}
+	}
+	if alias, ok := e.lookupAlias(sym); ok {
+		return alias.env.bindingFrame(alias.name)
+	}
+	return nil, sym
 }
 
+// Returns the operator bound to sym in the nearest frame that binds sym, or
+// nil if sym is not bound, or is bound to a variable there.
 func (e *LangEnv) getOperator(sym string) *Operator {
-	return e.opMap[sym]
+	frame, name := e.bindingFrame(sym)
+	if frame == nil {
+		return nil
+	}
+	return frame.opMap[name]
 }
 
+// Returns the value bound to sym in the nearest frame that binds sym, or nil
+// if sym is not bound, or is bound to an operator there.
 func (e *LangEnv) getValue(sym string) Value {
-	return e.varMap[sym]
+	frame, name := e.bindingFrame(sym)
+	if frame == nil {
+		return nil
+	}
+	return frame.varMap[name]
+}
+
+// Returns the name sym was renamed from by syntax-rules templates, or sym
+// itself if it is not an alias.
+func (e *LangEnv) baseName(sym string) string {
+	frame := e
+	for {
+		alias, ok := frame.lookupAlias(sym)
+		if !ok {
+			return sym
+		}
+		sym, frame = alias.name, alias.env
+	}
+}
+
+// Returns true if the node is the given keyword, like the else in a cond
+// clause. Keywords which come from a syntax-rules template count too.
+func (e *LangEnv) isKeyword(node *ASTNode, keyword string) bool {
+	return node.isValue && node.datum == nil && e.baseName(node.value) == keyword
+}
+
+// Binds a name to a value in this frame. If the value refers to an operator
+// (looked up in valEnv), the name is bound to that operator instead.
+func (e *LangEnv) bindValue(name string, val Value, valEnv *LangEnv) {
+	if val.getValueType() == varType {
+		if op := valEnv.getOperator(val.(varValue).varName); op != nil {
+			delete(e.varMap, name)
+			e.opMap[name] = op
+			return
+		}
+	}
+	delete(e.opMap, name)
+	e.varMap[name] = val
 }
@@ -0,0 +1,217 @@
+package lang
+
+import (
+	"errors"
+	"fmt"
+)
+
+// All the errors returned by the language embed an ErrorContext, which says
+// which operator they came from, and where in the source they happened.
+// Operator is empty if the error did not come from an operator, and Pos is
+// the zero Span if the position is not known.
+type ErrorContext struct {
+	Operator string
+	Pos      Span
+}
+
+func (c *ErrorContext) context() *ErrorContext {
+	return c
+}
+
+type langError interface {
+	error
+	context() *ErrorContext
+}
+
+// A SyntaxError is returned when an expression is malformed, like when a
+// bracket is not closed, or a let binding does not look like (name value).
+type SyntaxError struct {
+	ErrorContext
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return e.Msg
+}
+
+func newSyntaxError(format string, a ...interface{}) error {
+	err := new(SyntaxError)
+	err.Msg = fmt.Sprintf(format, a...)
+	return err
+}
+
+// An ArityError is returned when an operator, method or lambda is called with
+// too few, or too many arguments. Max is -1 if there is no maximum.
+type ArityError struct {
+	ErrorContext
+	Received int
+	Min      int
+	Max      int
+}
+
+func (e *ArityError) Error() string {
+	if e.Min == e.Max {
+		return fmt.Sprintf("Received %d arguments for operator %s, expected: %d",
+			e.Received, e.Operator, e.Min)
+	}
+	if e.Received < e.Min {
+		return fmt.Sprintf("Received %d arguments for operator %s, minimum expected arguments: %d",
+			e.Received, e.Operator, e.Min)
+	}
+	return fmt.Sprintf("Received %d arguments for operator %s, maximum expected arguments: %d",
+		e.Received, e.Operator, e.Max)
+}
+
+func newArityError(operator string, received, min, max int) error {
+	err := new(ArityError)
+	err.Operator = operator
+	err.Received = received
+	err.Min = min
+	err.Max = max
+	return err
+}
+
+// A TypeError is returned when operands are not of a type the operator can
+// work with.
+type TypeError struct {
+	ErrorContext
+	Operands []Value
+	Msg      string
+}
+
+func (e *TypeError) Error() string {
+	return e.Msg
+}
+
+func newTypeError(operator string, operands []Value, format string, a ...interface{}) error {
+	err := new(TypeError)
+	err.Operator = operator
+	err.Operands = operands
+	err.Msg = fmt.Sprintf(format, a...)
+	return err
+}
+
+// An UndefinedError is returned when a name is used, which is not bound to a
+// variable, or an operator.
+type UndefinedError struct {
+	ErrorContext
+	Name string
+	// True if the name was used as an operator, like foo in (foo 1).
+	IsOperator bool
+}
+
+func (e *UndefinedError) Error() string {
+	if e.IsOperator {
+		return fmt.Sprintf("Unknown operator '%s'", e.Name)
+	}
+	return fmt.Sprintf("Undefined variable: %s", e.Name)
+}
+
+func newUndefinedError(name string, isOperator bool) error {
+	err := new(UndefinedError)
+	err.Name = name
+	err.IsOperator = isOperator
+	return err
+}
+
+// A DivideByZeroError is returned when dividing by an exact zero.
+type DivideByZeroError struct {
+	ErrorContext
+	Operands []Value
+}
+
+func (e *DivideByZeroError) Error() string {
+	return "divide by zero"
+}
+
+func newDivideByZeroError(operator string, operands []Value) error {
+	err := new(DivideByZeroError)
+	err.Operator = operator
+	err.Operands = operands
+	return err
+}
+
+// A RecursionLimitError is returned when calls which are not in tail position
+// are nested too deeply.
+type RecursionLimitError struct {
+	ErrorContext
+	Limit int
+}
+
+func (e *RecursionLimitError) Error() string {
+	return fmt.Sprintf("Reached the recursion limit of %d. Terminating.", e.Limit)
+}
+
+func newRecursionLimitError(limit int) error {
+	err := new(RecursionLimitError)
+	err.Limit = limit
+	return err
+}
+
+// An EvalError is any other error which happens while evaluating, like
+// redefining a builtin operator.
+type EvalError struct {
+	ErrorContext
+	Msg string
+	err error
+}
+
+func (e *EvalError) Error() string {
+	return e.Msg
+}
+
+// Returns the error the EvalError was made from, if any.
+func (e *EvalError) Unwrap() error {
+	return e.err
+}
+
+func newEvalError(format string, a ...interface{}) error {
+	err := new(EvalError)
+	err.Msg = fmt.Sprintf(format, a...)
+	return err
+}
+
+// Attaches a span to an error, unless it already has one. Errors get the span
+// of the innermost expression they came from this way. Errors which are not
+// from this package are turned into an EvalError.
+func withSpan(err error, span Span) error {
+	if err == nil || span.Line == 0 {
+		return err
+	}
+	ctx := errorContext(err)
+	if ctx == nil {
+		evalErr := new(EvalError)
+		evalErr.Msg = err.Error()
+		evalErr.err = err
+		err = evalErr
+		ctx = evalErr.context()
+	}
+	if ctx.Pos.Line == 0 {
+		ctx.Pos = span
+	}
+	return err
+}
+
+// Records the operator an error came from, unless it is already known.
+func withOperator(err error, operator string) error {
+	if ctx := errorContext(err); ctx != nil && len(ctx.Operator) == 0 {
+		ctx.Operator = operator
+	}
+	return err
+}
+
+func errorContext(err error) *ErrorContext {
+	var langErr langError
+	if errors.As(err, &langErr) {
+		return langErr.context()
+	}
+	return nil
+}
+
+// Returns the span attached to an error, or nil if there isn't one.
+func errorSpan(err error) *Span {
+	if ctx := errorContext(err); ctx != nil && ctx.Pos.Line != 0 {
+		return &ctx.Pos
+	}
+	return nil
+}
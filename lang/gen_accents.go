@@ -0,0 +1,124 @@
+//go:build ignore
+
+// This program generates accents_table.go, which maps the characters with
+// accents to the characters they are written on, from the canonical
+// decompositions in UnicodeData.txt of the Unicode Character Database:
+//
+//	go run gen_accents.go -data UnicodeData.txt -o accents_table.go
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+func main() {
+	dataPath := flag.String("data", "UnicodeData.txt", "path of UnicodeData.txt")
+	outPath := flag.String("o", "accents_table.go", "path of the file to generate")
+	version := flag.String("version", "", "version of the Unicode Character Database")
+	flag.Parse()
+
+	decompositions, err := readDecompositions(*dataPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Every character is decomposed completely, and the combining marks are
+	// dropped. The ones which are left with a single other character are the
+	// ones with accents.
+	bases := make(map[rune]rune)
+	for r := range decompositions {
+		var kept []rune
+		for _, d := range decompose(decompositions, r) {
+			if !unicode.Is(unicode.Mn, d) {
+				kept = append(kept, d)
+			}
+		}
+		if len(kept) == 1 && kept[0] != r {
+			bases[r] = kept[0]
+		}
+	}
+	runes := make([]rune, 0, len(bases))
+	for r := range bases {
+		runes = append(runes, r)
+	}
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "// Code generated by gen_accents.go from the Unicode Character Database")
+	if len(*version) > 0 {
+		fmt.Fprintf(&buffer, " %s", *version)
+	}
+	fmt.Fprintf(&buffer, ". DO NOT EDIT.\n\npackage lang\n\n")
+	fmt.Fprintf(&buffer, "// Maps each character with accents to the character they are written on.\n")
+	fmt.Fprintf(&buffer, "var accentBases = map[rune]rune{\n")
+	for _, r := range runes {
+		fmt.Fprintf(&buffer, "%#04x: %#04x, // %c → %c\n", r, bases[r], r, bases[r])
+	}
+	fmt.Fprintf(&buffer, "}\n")
+
+	src, err := format.Source(buffer.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(*outPath, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// Returns the canonical decompositions in UnicodeData.txt. Compatibility
+// decompositions, which start with a tag like <font>, are left out.
+func readDecompositions(path string) (map[rune][]rune, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	decompositions := make(map[rune][]rune)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), ";")
+		if len(fields) < 6 || len(fields[5]) == 0 || strings.HasPrefix(fields[5], "<") {
+			continue
+		}
+		code, err := strconv.ParseUint(fields[0], 16, 32)
+		if err != nil {
+			return nil, err
+		}
+		var parts []rune
+		for _, part := range strings.Fields(fields[5]) {
+			p, err := strconv.ParseUint(part, 16, 32)
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, rune(p))
+		}
+		decompositions[rune(code)] = parts
+	}
+	return decompositions, scanner.Err()
+}
+
+// Returns the characters r decomposes to, once none of them decompose any
+// further.
+func decompose(decompositions map[rune][]rune, r rune) []rune {
+	parts, ok := decompositions[r]
+	if !ok {
+		return []rune{r}
+	}
+	var result []rune
+	for _, p := range parts {
+		result = append(result, decompose(decompositions, p)...)
+	}
+	return result
+}
@@ -0,0 +1,439 @@
+package lang
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+const (
+	// Hash map operators
+	makeHash    string = "hash"
+	hashRef     string = "hash-ref"
+	hashSet     string = "hash-set"
+	hashRemove  string = "hash-remove"
+	hashHasKey  string = "hash-has-key?"
+	hashKeys    string = "hash-keys"
+	hashValues  string = "hash-values"
+	hashCount   string = "hash-count"
+	hashToList  string = "hash->list"
+	hashForEach string = "hash-for-each"
+)
+
+// A hashEntry is a key in a hash map, along with the value it maps to.
+type hashEntry struct {
+	key   Value
+	value Value
+}
+
+// A hashMapValue maps keys to values. The entries are stored by the hash key
+// of their key, so keys which are the same structurally, like two lists with
+// the same elements, map to the same entry. Hash maps are never changed once
+// they are built: hash-set and hash-remove return new ones.
+type hashMapValue struct {
+	entries map[string]hashEntry
+}
+
+func newHashMapValue() hashMapValue {
+	var val hashMapValue
+	val.entries = make(map[string]hashEntry)
+	return val
+}
+
+func (v hashMapValue) getValueType() valueType {
+	return hashMapType
+}
+
+func (v hashMapValue) to(targetType valueType) (Value, error) {
+	switch targetType {
+	case hashMapType:
+		return v, nil
+	}
+	return nil, typeConvError(v.getValueType(), targetType)
+}
+
+// Hash maps are built with {k v ...}, which is read as (hash k v ...), so
+// there is no token for them.
+func (v hashMapValue) ofType(targetValue string) bool {
+	return false
+}
+
+func (v hashMapValue) Str() string {
+	return v.format(Value.Str)
+}
+
+// Returns the hash map as {k v ...}, with the keys in order, and the keys and
+// values printed by elemStr.
+func (v hashMapValue) format(elemStr func(Value) string) string {
+	parts := make([]string, 0, 2*len(v.entries))
+	for _, entry := range v.sortedEntries() {
+		parts = append(parts, elemStr(entry.key), elemStr(entry.value))
+	}
+	return "{" + strings.Join(parts, " ") + "}"
+}
+
+func (v hashMapValue) newValue(str string) Value {
+	return nil
+}
+
+// Returns the entries ordered by their keys, so that equal hash maps print the
+// same way. Real numbers come in numerical order, and everything else is
+// grouped by its type and ordered by how it prints.
+func (v hashMapValue) sortedEntries() []hashEntry {
+	entries := make([]hashEntry, 0, len(v.entries))
+	for _, entry := range v.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return compareKeys(entries[i].key, entries[j].key) < 0
+	})
+	return entries
+}
+
+// Returns a copy of the hash map, which can be changed without changing the
+// original.
+func (v hashMapValue) copy() hashMapValue {
+	val := newHashMapValue()
+	for k, entry := range v.entries {
+		val.entries[k] = entry
+	}
+	return val
+}
+
+func compareKeys(a, b Value) int {
+	if isNumber(a) && isNumber(b) {
+		if c, ordered := compareReals(a, b); ordered && c != 0 {
+			return c
+		}
+	}
+	aType, bType := a.getValueType().(string), b.getValueType().(string)
+	if aType != bType {
+		return strings.Compare(aType, bType)
+	}
+	if c := strings.Compare(a.Str(), b.Str()); c != 0 {
+		return c
+	}
+	aKey, _ := hashKey(a)
+	bKey, _ := hashKey(b)
+	return strings.Compare(aKey, bKey)
+}
+
+// This method returns the string a value is stored under, when it is used as a
+// key in a hash map. Numbers which are =, like 1, 2/2, 1.0m, 1.0 and 1+0i, have
+// the same hash key, so a key can be looked up with any number equal to it.
+// Lists and hash maps are hashed by their contents. Methods can not be hashed,
+// and neither can vectors, as they can change after they are used as a key.
+func hashKey(v Value) (string, error) {
+	switch val := v.(type) {
+	case intValue, bigIntValue, ratValue, decimalValue:
+		r, err := toRat(v)
+		if err != nil {
+			return "", err
+		}
+		return "n" + r.RatString(), nil
+	case floatValue:
+		// Every finite float is a fraction, which exact numbers equal to it
+		// share their key with.
+		if isFinite(val.value) {
+			return "n" + new(big.Rat).SetFloat64(val.value).RatString(), nil
+		}
+		return val.getValueType().(string) + ":" + val.Str(), nil
+	case complexValue:
+		if imag(val.value) == 0 {
+			return hashKey(newFloatValue(real(val.value)))
+		}
+		return val.getValueType().(string) + ":" + val.Str(), nil
+	case stringValue, charValue, boolValue, symbolValue, emptyListValue:
+		return val.getValueType().(string) + ":" + val.Str(), nil
+	case pairValue:
+		var buffer bytes.Buffer
+		buffer.WriteString("(")
+		var rest Value = val
+		for {
+			pair, ok := rest.(pairValue)
+			if !ok {
+				break
+			}
+			key, err := hashKey(pair.car)
+			if err != nil {
+				return "", err
+			}
+			buffer.WriteString(key + " ")
+			rest = pair.cdr
+		}
+		key, err := hashKey(rest)
+		if err != nil {
+			return "", err
+		}
+		buffer.WriteString(". " + key + ")")
+		return buffer.String(), nil
+	case hashMapValue:
+		keys := make([]string, 0, 2*len(val.entries))
+		for k, entry := range val.entries {
+			valueKey, err := hashKey(entry.value)
+			if err != nil {
+				return "", err
+			}
+			keys = append(keys, k+" "+valueKey)
+		}
+		sort.Strings(keys)
+		return "{" + strings.Join(keys, " ") + "}", nil
+	}
+	return "", newTypeError("", []Value{v}, "%s can not be used as a key in a hash map.", v.Str())
+}
+
+// Returns the hash map operand, after checking that it is one.
+func hashMapArg(operator string, operand Atom) (hashMapValue, error) {
+	operands := []Atom{operand}
+	if _, err := checkArgTypes(operator, &operands, []valueType{hashMapType}); err != nil {
+		return hashMapValue{}, err
+	}
+	return operand.Val.(hashMapValue), nil
+}
+
+// This method calls the method with the operands, and evaluates the result
+// completely. The method has to be a procedure value: a lambda, or an
+// operator like car. Anything else, like the symbol 'car, is not called.
+func callMethod(env *LangEnv, method Value, operands []Atom) Atom {
+	var result Atom
+	switch val := method.(type) {
+	case lambdaValue:
+		result = callLambda(env, val, operands)
+	case varValue:
+		operator := env.getOperator(val.varName)
+		if operator == nil {
+			result.Err = newUndefinedError(val.varName, true)
+			return result
+		}
+		result = applyOperator(env, operator, operands)
+	default:
+		result.Err = newTypeError("", []Value{method}, "%s is not a procedure.", method.Str())
+		return result
+	}
+	if tailCall, ok := result.Val.(tailCallValue); ok {
+		result = evalASTHelper(tailCall.env, tailCall.node)
+	}
+	return result
+}
+
+func addHashMapOperators(opMap map[string]*Operator) {
+	// (hash k v ...) is the hash map in which each k maps to the v after it.
+	// A key which appears more than once maps to the last value given for it.
+	addOperator(opMap,
+		&Operator{
+			symbol:      makeHash,
+			minArgCount: 0,
+			maxArgCount: math.MaxInt32,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				if len(operands)%2 != 0 {
+					retVal.Err = newTypeError(makeHash, nil,
+						"Expected keys and values in pairs, got %d values.", len(operands))
+					return retVal
+				}
+				val := newHashMapValue()
+				for i := 0; i < len(operands); i += 2 {
+					key, err := hashKey(operands[i].Val)
+					if err != nil {
+						retVal.Err = err
+						return retVal
+					}
+					val.entries[key] = hashEntry{operands[i].Val, operands[i+1].Val}
+				}
+				retVal.Val = val
+				return retVal
+			},
+		},
+	)
+
+	// (hash-ref m k default) is the value k maps to in m. If there is none, it
+	// is default, or an error if default is left out.
+	addOperator(opMap,
+		&Operator{
+			symbol:      hashRef,
+			minArgCount: 2,
+			maxArgCount: 3,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				hashMap, err := hashMapArg(hashRef, operands[0])
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				key, err := hashKey(operands[1].Val)
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				if entry, ok := hashMap.entries[key]; ok {
+					retVal.Val = entry.value
+				} else if len(operands) == 3 {
+					retVal.Val = operands[2].Val
+				} else {
+					retVal.Err = newEvalError("No value for the key %s in %s.",
+						operands[1].Val.Str(), hashMap.Str())
+				}
+				return retVal
+			},
+		},
+	)
+
+	// (hash-set m k v) is a copy of m, in which k maps to v.
+	addOperator(opMap,
+		&Operator{
+			symbol:      hashSet,
+			minArgCount: 3,
+			maxArgCount: 3,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				hashMap, err := hashMapArg(hashSet, operands[0])
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				key, err := hashKey(operands[1].Val)
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				val := hashMap.copy()
+				val.entries[key] = hashEntry{operands[1].Val, operands[2].Val}
+				retVal.Val = val
+				return retVal
+			},
+		},
+	)
+
+	// (hash-remove m k) is a copy of m, without k.
+	addOperator(opMap,
+		&Operator{
+			symbol:      hashRemove,
+			minArgCount: 2,
+			maxArgCount: 2,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				hashMap, err := hashMapArg(hashRemove, operands[0])
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				key, err := hashKey(operands[1].Val)
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				val := hashMap.copy()
+				delete(val.entries, key)
+				retVal.Val = val
+				return retVal
+			},
+		},
+	)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      hashHasKey,
+			minArgCount: 2,
+			maxArgCount: 2,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				hashMap, err := hashMapArg(hashHasKey, operands[0])
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				key, err := hashKey(operands[1].Val)
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				_, ok := hashMap.entries[key]
+				retVal.Val = newBoolValue(ok)
+				return retVal
+			},
+		},
+	)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      hashCount,
+			minArgCount: 1,
+			maxArgCount: 1,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				hashMap, err := hashMapArg(hashCount, operands[0])
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				var val intValue
+				val.value = int64(len(hashMap.entries))
+				retVal.Val = val
+				return retVal
+			},
+		},
+	)
+
+	// hash-keys, hash-values and hash->list give the keys, the values, and the
+	// (k . v) pairs of a hash map, as lists in the order the keys are printed.
+	listings := map[string]func(hashEntry) Value{
+		hashKeys:   func(e hashEntry) Value { return e.key },
+		hashValues: func(e hashEntry) Value { return e.value },
+		hashToList: func(e hashEntry) Value { return newPairValue(e.key, e.value) },
+	}
+	for _, symbol := range []string{hashKeys, hashValues, hashToList} {
+		listSymbol := symbol
+		listing := listings[listSymbol]
+		addOperator(opMap,
+			&Operator{
+				symbol:      listSymbol,
+				minArgCount: 1,
+				maxArgCount: 1,
+				handler: func(env *LangEnv, operands []Atom) Atom {
+					var retVal Atom
+					hashMap, err := hashMapArg(listSymbol, operands[0])
+					if err != nil {
+						retVal.Err = err
+						return retVal
+					}
+					values := make([]Value, 0, len(hashMap.entries))
+					for _, entry := range hashMap.sortedEntries() {
+						values = append(values, listing(entry))
+					}
+					retVal.Val = newList(values)
+					return retVal
+				},
+			},
+		)
+	}
+
+	// (hash-for-each m f) calls f with each key and its value, in the order
+	// the keys are printed.
+	addOperator(opMap,
+		&Operator{
+			symbol:      hashForEach,
+			minArgCount: 2,
+			maxArgCount: 2,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				hashMap, err := hashMapArg(hashForEach, operands[0])
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				for _, entry := range hashMap.sortedEntries() {
+					var key, value Atom
+					key.Val, value.Val = entry.key, entry.value
+					result := callMethod(env, operands[1].Val, []Atom{key, value})
+					if result.Err != nil {
+						return result
+					}
+				}
+				retVal.Val = voidValue{}
+				return retVal
+			},
+		},
+	)
+}
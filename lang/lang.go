@@ -1,9 +1,6 @@
 package lang
 
 import (
-	"bytes"
-	"errors"
-	"fmt"
 	"strings"
 )
 
@@ -13,18 +10,45 @@ type Atom struct {
 	Val Value
 }
 
+// The maximum depth of non-tail recursion.
+const maxRecursionLimit = 100000
+
 type EvalResult struct {
 	ValStr          string
 	ErrStr          string
 	RemainingTokens string
+	// The error itself, like a *SyntaxError or a *TypeError.
+	Err error
+	// The part of the source which caused the error, if it is known.
+	ErrSpan *Span
 }
 
 func Eval(exp string, env *LangEnv) *EvalResult {
 	exp = strings.TrimSpace(exp)
+	if len(exp) == 0 {
+		evalResult := new(EvalResult)
+		evalResult.Err = newSyntaxError("Nothing to evaluate")
+		evalResult.ErrStr = evalResult.Err.Error()
+		return evalResult
+	}
+	src := NewSource("", exp)
+	evalResult := src.Eval(env)
+	evalResult.RemainingTokens = src.remainingTokens()
+	return evalResult
+}
+
+// Evaluates the next expression in the source.
+func (s *Source) Eval(env *LangEnv) *EvalResult {
 	evalResult := new(EvalResult)
-	astNode, tokens, err := getAST(exp)
+	astNode, err := s.read()
 	if err != nil {
+		evalResult.Err = err
 		evalResult.ErrStr = err.Error()
+		evalResult.ErrSpan = errorSpan(err)
+		return evalResult
+	}
+	if astNode == nil {
+		// There was nothing but comments.
 		return evalResult
 	}
 
@@ -34,24 +58,13 @@ func Eval(exp string, env *LangEnv) *EvalResult {
 	// Remove this hack, pending: https://github.com/golang/go/issues/11318
 	result := evalASTHelper(env, astNode)
 
-	if result.Val != nil && result.Val.getValueType() == varType {
-		result.Val, result.Err = getVarValue(env, result.Val)
-	}
-
 	if result.Err != nil {
+		evalResult.Err = result.Err
 		evalResult.ErrStr = result.Err.Error()
-	} else if result.Val != nil {
+		evalResult.ErrSpan = errorSpan(result.Err)
+	} else if result.Val != nil && result.Val.getValueType() != voidType {
 		evalResult.ValStr = result.Val.Str()
 	}
-
-	if tokens != nil && len(tokens) > 0 {
-		var buffer bytes.Buffer
-		for _, s := range tokens {
-			buffer.WriteString(s)
-			buffer.WriteString(" ")
-		}
-		evalResult.RemainingTokens = strings.TrimSpace(buffer.String())
-	}
 	return evalResult
 }
 
@@ -60,86 +73,306 @@ func evalASTHelper(env *LangEnv, node *ASTNode) Atom {
 
 	if result.Val != nil && result.Val.getValueType() == varType {
 		result.Val, result.Err = getVarValue(env, result.Val)
+		result.Err = withSpan(result.Err, node.span)
 	}
 	return result
 }
 
+// This method evaluates a node completely. Expressions in tail position, like
+// the body of a lambda or the chosen branch of a cond, are handed back by
+// evalStep as tail calls, and evaluated here in a loop, so that tail recursion
+// does not grow the Go stack.
 func evalAST(env *LangEnv, node *ASTNode) Atom {
+	retVal := evalStep(env, node)
+	retVal.Err = withSpan(retVal.Err, node.span)
+	tailCall, ok := retVal.Val.(tailCallValue)
+	if !ok {
+		return retVal
+	}
+
+	// An evaluation counts as one level of recursion, no matter how many tail
+	// calls it makes.
+	global := env.global()
+	global.recursionDepth++
+	defer func() { global.recursionDepth-- }()
+	if global.recursionDepth > maxRecursionLimit {
+		retVal.Val = nil
+		retVal.Err = withSpan(newRecursionLimitError(maxRecursionLimit), node.span)
+		return retVal
+	}
+
+	for {
+		retVal = evalStep(tailCall.env, tailCall.node)
+		retVal.Err = withSpan(retVal.Err, tailCall.node.span)
+		if retVal.Val == nil {
+			return retVal
+		}
+		if retVal.Val.getValueType() == varType {
+			// Variables in tail position belong to the environment of the tail
+			// call, so they have to be resolved here.
+			retVal.Val, retVal.Err = getVarValue(tailCall.env, retVal.Val)
+			retVal.Err = withSpan(retVal.Err, tailCall.node.span)
+			return retVal
+		}
+		if tailCall, ok = retVal.Val.(tailCallValue); !ok {
+			return retVal
+		}
+	}
+}
+
+func evalStep(env *LangEnv, node *ASTNode) Atom {
 	// printVarMap(env.varMap)
 	var retVal Atom
 	retVal.Err = nil
 
+	if node.datum != nil {
+		retVal.Val = node.datum
+		return retVal
+	}
 	if node.isValue {
 		value, err := getValue(env, node.value)
 		if err != nil {
-			retVal.Err = errors.New(fmt.Sprintf("%s %s", errStr("value", node.value), err))
+			retVal.Err = newSyntaxError("%s %s", errStr("value", node.value), err)
 		} else {
 			retVal.Val = value
 		}
 		return retVal
 	}
 	if len(node.children) == 0 {
-		retVal.Err = errors.New("Cannot evaluate an empty expression")
+		retVal.Err = newSyntaxError("Cannot evaluate an empty expression")
 		return retVal
 	}
 	if len(node.children) == 1 {
-		return evalAST(env, node.children[0])
+		// A single callable child, like (foo), is a call without arguments.
+		// Anything else, like (x), is just the value of the child.
+		head := node.children[0]
+		if head.isValue && env.getOperator(head.value) != nil {
+			return evalOperator(env, node, env.getOperator(head.value))
+		}
+		headVal := evalASTHelper(env, head)
+		if undefErr, ok := headVal.Err.(*UndefinedError); ok && head.isValue && undefErr.Name == head.value {
+			// Like the foo in (foo 1), the foo in (foo) is used as an operator.
+			headVal.Err = newUndefinedError(head.value, true)
+			return headVal
+		}
+		if lambdaVal, ok := headVal.Val.(lambdaValue); ok {
+			return callLambda(env, lambdaVal, make([]Atom, 0))
+		}
+		return headVal
 	}
 
 	// Assuming that the first child is an operand
 	symbol := node.children[0].value
 	operator := env.getOperator(symbol)
-	if operator == nil {
-		retVal.Err = errors.New(fmt.Sprintf("Unknown operator '%s'", symbol))
-		return retVal
+	if operator != nil {
+		return evalOperator(env, node, operator)
 	}
 
-	if operator.minArgCount == operator.maxArgCount {
-		argCount := operator.minArgCount
-		if len(node.children)-1 != argCount {
-			retVal.Err = errors.New(
-				fmt.Sprintf("Received %d arguments for operator %s, expected: %d",
-					len(node.children)-1, symbol, argCount))
-			return retVal
-		}
-	} else {
-		if len(node.children)-1 < operator.minArgCount {
-			retVal.Err = errors.New(
-				fmt.Sprintf("Received %d arguments for operator %s, minimum expected arguments: %d",
-					len(node.children)-1, symbol, operator.minArgCount))
-			return retVal
-		} else if len(node.children)-1 > operator.maxArgCount {
-			retVal.Err = errors.New(
-				fmt.Sprintf("Received %d arguments for operator %s, maximum expected arguments: %d",
-					len(node.children)-1, symbol, operator.maxArgCount))
+	// The first child might also evaluate to a lambda, either through a
+	// variable, or because it is an expression like (lambda (x) ...). Only a
+	// name which is not bound is undefined. Anything else which is not a
+	// lambda, like the 1 in (1 2), is not a procedure.
+	head := node.children[0]
+	headVal := evalASTHelper(env, head)
+	if undefErr, ok := headVal.Err.(*UndefinedError); ok && head.isValue && undefErr.Name == symbol {
+		retVal.Err = newUndefinedError(symbol, true)
+		return retVal
+	}
+	if headVal.Err != nil {
+		return headVal
+	}
+	if lambdaVal, ok := headVal.Val.(lambdaValue); ok {
+		operands, err := evalOperands(env, node.children[1:], false)
+		if err != nil {
+			retVal.Err = err
 			return retVal
 		}
+		return callLambda(env, lambdaVal, operands)
 	}
+	retVal.Err = newTypeError("", []Value{headVal.Val}, "%s is not a procedure.", StringifyAST(head))
+	return retVal
+}
 
-	operands := make([]Atom, 0)
+func evalOperator(env *LangEnv, node *ASTNode, operator *Operator) Atom {
+	var retVal Atom
+	symbol := operator.symbol
+	argCount := len(node.children) - 1
+	if argCount < operator.minArgCount || argCount > operator.maxArgCount {
+		retVal.Err = newArityError(symbol, argCount, operator.minArgCount, operator.maxArgCount)
+		return retVal
+	}
+
+	var operands []Atom
 	if operator.passRawAST {
 		var o Atom
 		o.Val = newASTValue(node)
 		operands = append(operands, o)
 	} else {
-		for i := 1; i < len(node.children); i++ {
-			v := evalAST(env, node.children[i])
-			if v.Err != nil {
-				return v
-			}
-			if !operator.doNotResolveVars && v.Val.getValueType() == varType {
-				v.Val, v.Err = getVarValue(env, v.Val)
-				if v.Err != nil {
-					return v
-				}
-			}
-			operands = append(operands, v)
+		var err error
+		operands, err = evalOperands(env, node.children[1:], operator.doNotResolveVars)
+		if err != nil {
+			retVal.Err = err
+			return retVal
 		}
 	}
 	v := operator.handler(env, operands)
 	if v.Err != nil {
+		v.Err = withOperator(v.Err, symbol)
 		return v
 	}
 	retVal.Val = v.Val
 	return retVal
 }
+
+func evalOperands(env *LangEnv, nodes []*ASTNode, doNotResolveVars bool) ([]Atom, error) {
+	operands := make([]Atom, 0)
+	for _, n := range nodes {
+		v := evalAST(env, n)
+		if v.Err != nil {
+			return nil, v.Err
+		}
+		if !doNotResolveVars && v.Val.getValueType() == varType {
+			v.Val, v.Err = getVarValue(env, v.Val)
+			if v.Err != nil {
+				return nil, withSpan(v.Err, n.span)
+			}
+		}
+		operands = append(operands, v)
+	}
+	return operands, nil
+}
+
+// This method calls a lambda with the given operands. The body is evaluated
+// in a new environment built on top of the one the lambda was defined in.
+func callLambda(env *LangEnv, lambdaVal lambdaValue, operands []Atom) Atom {
+	var retVal Atom
+	paramCount := len(lambdaVal.params)
+	if len(lambdaVal.rest) == 0 && len(operands) != paramCount {
+		retVal.Err = newArityError(lambda, len(operands), paramCount, paramCount)
+		return retVal
+	}
+	if len(operands) < paramCount {
+		retVal.Err = newArityError(lambda, len(operands), paramCount, -1)
+		return retVal
+	}
+
+	// Formal arguments are favored over previously defined variables.
+	newEnv := newChildEnv(lambdaVal.env)
+	for i, p := range lambdaVal.params {
+		newEnv.bindValue(p, operands[i].Val, env)
+	}
+	if len(lambdaVal.rest) > 0 {
+		rest := make([]Value, 0, len(operands)-paramCount)
+		for _, o := range operands[paramCount:] {
+			rest = append(rest, o.Val)
+		}
+		newEnv.bindValue(lambdaVal.rest, newList(rest), env)
+	}
+	return evalBody(newEnv, lambdaVal.body)
+}
+
+// This method calls the method the node refers to with operands which have
+// already been evaluated. The node can either name an operator, like car, or
+// evaluate to a lambda.
+func applyMethod(env *LangEnv, node *ASTNode, operands []Atom) Atom {
+	var retVal Atom
+	if node.isValue {
+		if operator := env.getOperator(node.value); operator != nil {
+			return applyOperator(env, operator, operands)
+		}
+	}
+
+	headVal := evalASTHelper(env, node)
+	if headVal.Err != nil {
+		return headVal
+	}
+	lambdaVal, ok := headVal.Val.(lambdaValue)
+	if !ok {
+		retVal.Err = newTypeError("", []Value{headVal.Val}, "%s is not a procedure.", StringifyAST(node))
+		return retVal
+	}
+	return callLambda(env, lambdaVal, operands)
+}
+
+// This method calls the operator with operands which have already been
+// evaluated. Special forms, like if, need their operands unevaluated, so they
+// can not be called this way.
+func applyOperator(env *LangEnv, operator *Operator, operands []Atom) Atom {
+	var retVal Atom
+	if operator.passRawAST {
+		retVal.Err = newTypeError(operator.symbol, nil,
+			"Cannot apply %s to values, as it is a special form.", operator.symbol)
+		return retVal
+	}
+	if len(operands) < operator.minArgCount || len(operands) > operator.maxArgCount {
+		retVal.Err = newArityError(operator.symbol, len(operands),
+			operator.minArgCount, operator.maxArgCount)
+		return retVal
+	}
+	retVal = operator.handler(env, operands)
+	retVal.Err = withOperator(retVal.Err, operator.symbol)
+	return retVal
+}
+
+// This method evaluates a non-empty sequence of expressions in order. The last
+// one is in tail position, so it is returned as a tail call, to be evaluated
+// by evalAST.
+func evalBody(env *LangEnv, nodes []*ASTNode) Atom {
+	last := len(nodes) - 1
+	for _, node := range nodes[:last] {
+		retVal := evalASTHelper(env, node)
+		if retVal.Err != nil {
+			return retVal
+		}
+	}
+	return newTailCall(env, nodes[last])
+}
+
+// This method checks that the node is a list of parameter names, and returns
+// them. A list like (a b . rest) ends with a rest parameter, which is returned
+// separately.
+func getParams(env *LangEnv, node *ASTNode, methodName string) ([]string, string, error) {
+	if node.isValue {
+		return nil, "", newSyntaxError("Missing list of parameters for method %s", methodName)
+	}
+
+	params := make([]string, 0)
+	rest := ""
+	children := node.children
+	if n := len(children); n >= 2 && children[n-2].isValue && children[n-2].value == dot {
+		rest = children[n-1].value
+		if !children[n-1].isValue || !isParamName(env, rest) {
+			return nil, "", newSyntaxError("Malformed rest parameter %s in method %s.",
+				StringifyAST(children[n-1]), methodName)
+		}
+		children = children[:n-2]
+	}
+	for i, child := range children {
+		if !child.isValue {
+			return nil, "", newSyntaxError("Malformed parameter %d in method %s.", i, methodName)
+		}
+		paramName := child.value
+		if !isParamName(env, paramName) {
+			return nil, "", newSyntaxError("Malformed parameter %s in method %s.", paramName, methodName)
+		}
+		params = append(params, paramName)
+	}
+	return params, rest, nil
+}
+
+func isParamName(env *LangEnv, name string) bool {
+	val, err := getValue(env, name)
+	return err == nil && val.getValueType() == varType
+}
+
+// This method checks that the node is a name which a value can be bound to,
+// and returns it.
+func getVarName(env *LangEnv, node *ASTNode, operator string) (string, error) {
+	if node.isValue {
+		val, err := getValue(env, node.value)
+		if err == nil && val.getValueType() == varType {
+			return node.value, nil
+		}
+	}
+	return "", newSyntaxError("Expected a variable name for %s, got %s.", operator, StringifyAST(node))
+}
@@ -1,6 +1,8 @@
 package lang
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"math/rand"
 	"testing"
@@ -73,7 +75,13 @@ func TestBasicLang(t *testing.T) {
 	checkExprResultTest("(* 1 2 3 4 5)", "120", t, env)
 	checkExprResultTest("(* 111111111111111111111111111111111111111111111111 2)",
 		"222222222222222222222222222222222222222222222222", t, env)
-	checkExprResultTest("(/ 1 2)", "0", t, env)
+	var ones bytes.Buffer
+	for i := 0; i < 150; i++ {
+		ones.WriteString("1 ")
+	}
+	checkExprResultTest(fmt.Sprintf("(+ %s)", ones.String()), "150", t, env)
+	checkExprResultTest(fmt.Sprintf("(* 2 %s)", ones.String()), "2", t, env)
+	checkExprResultTest("(/ 1 2)", "1/2", t, env)
 	checkExprResultTest("(/ 111111111111111111111111111111111111111111111111 1)", "111111111111111111111111111111111111111111111111", t, env)
 
 	checkExprResultTest("(+ 1.1 2.1)", "3.2", t, env)
@@ -131,6 +139,33 @@ func TestBasicLang(t *testing.T) {
 	checkExprResultTest("(or true true true true)", "true", t, env)
 	checkExprResultTest("(or false false false true)", "true", t, env)
 
+	// and and or return the value which decided the result, and do not
+	// evaluate the operands after it.
+	checkExprResultTest("(and)", "true", t, env)
+	checkExprResultTest("(or)", "false", t, env)
+	checkExprResultTest("(and 1 2 3)", "3", t, env)
+	checkExprResultTest("(and 1 false 3)", "false", t, env)
+	checkExprResultTest("(or false 2 3)", "2", t, env)
+	checkExprResultTest("(or false false)", "false", t, env)
+	checkExprResultTest("(and false (/ 1 0))", "false", t, env)
+	var falses bytes.Buffer
+	for i := 0; i < 150; i++ {
+		falses.WriteString("false ")
+	}
+	checkExprResultTest(fmt.Sprintf("(or %s 7)", falses.String()), "7", t, env)
+	checkExprResultTest(fmt.Sprintf("(and 1 %s)", falses.String()), "false", t, env)
+	checkExprResultTest("(or \"yes\" (/ 1 0))", "\"yes\"", t, env)
+	checkExprResultTest("(and (> 0 0) (= (/ 10 0) 2))", "false", t, env)
+	malformedExprTest("(and true (/ 1 0))", t, env)
+
+	checkExprResultTest("(not true)", "false", t, env)
+	checkExprResultTest("(not false)", "true", t, env)
+	checkExprResultTest("(not 0)", "false", t, env)
+	checkExprResultTest("(not '())", "false", t, env)
+	checkExprResultTest("(not (and true false))", "true", t, env)
+	malformedExprTest("(not)", t, env)
+	malformedExprTest("(not true false)", t, env)
+
 	checkExprResultTest("(cond (true 1) (false 2))", "1", t, env)
 	checkExprResultTest("(cond (false 1) (true 2))", "2", t, env)
 	checkExprResultTest("(cond (false 1) (true 2) (false 3))", "2", t, env)
@@ -145,8 +180,8 @@ func TestBasicLang(t *testing.T) {
 
 	malformedExprTest("(/ 1 0)", t, env)
 
-	malformedExprTest("(cond (1 2))", t, env)
-	malformedExprTest("(cond (false 1) (false 2))", t, env)
+	malformedExprTest("(cond 1)", t, env)
+	malformedExprTest("(cond ())", t, env)
 
 	runRandomSmokeTests(t, env)
 }
@@ -157,9 +192,7 @@ func TestMethods(t *testing.T) {
 
 	saneExprTest("(defun foo (x) (+ 1 x))", t, env)
 	checkExprResultTest("(foo 4)", "5", t, env)
-	// TODO: This should fail
-	// See https://github.com/reddragon/lambda/issues/10
-	// malformedExprTest("(foo)", t, env)
+	malformedExprTest("(foo)", t, env)
 	malformedExprTest("(foo 4 5)", t, env)
 
 	saneExprTest("(defvar p 1)", t, env)
@@ -190,6 +223,1414 @@ func TestMethods(t *testing.T) {
 	checkExprResultTest("(twice add-one 2)", "4", t, env)
 }
 
+func TestLambdas(t *testing.T) {
+	env := new(LangEnv)
+	env.Init()
+
+	checkExprResultTest("((lambda (x) (* x x)) 3)", "9", t, env)
+	checkExprResultTest("((lambda () 42))", "42", t, env)
+	malformedExprTest("((lambda (x) x))", t, env)
+	malformedExprTest("((lambda (x) x) 1 2)", t, env)
+	malformedExprTest("(lambda x x)", t, env)
+	malformedExprTest("(lambda (1) x)", t, env)
+
+	// Lambdas can be stored in variables, and called through them.
+	saneExprTest("(defvar sq (lambda (x) (* x x)))", t, env)
+	checkExprResultTest("(sq 5)", "25", t, env)
+
+	// Lambdas can be passed as arguments to methods and other lambdas.
+	saneExprTest("(defun twice (f x) (f (f x)))", t, env)
+	checkExprResultTest("(twice sq 3)", "81", t, env)
+	checkExprResultTest("(twice (lambda (x) (+ x 1)) 3)", "5", t, env)
+	checkExprResultTest("((lambda (f) (f 4)) sq)", "16", t, env)
+
+	// Lambdas capture the environment they were defined in.
+	saneExprTest("(defun make-adder (n) (lambda (x) (+ x n)))", t, env)
+	saneExprTest("(defvar add-two (make-adder 2))", t, env)
+	checkExprResultTest("(add-two 40)", "42", t, env)
+	checkExprResultTest("((make-adder 10) 5)", "15", t, env)
+	saneExprTest("(defvar n 100)", t, env)
+	checkExprResultTest("(add-two 1)", "3", t, env)
+	checkExprResultTest("(((lambda (a) (lambda (b) (- a b))) 10) 3)", "7", t, env)
+
+	malformedExprTest("(1 2)", t, env)
+	malformedExprTest("(undefinedMethod 2)", t, env)
+}
+
+func TestRationals(t *testing.T) {
+	env := new(LangEnv)
+	env.Init()
+
+	checkExprResultTest("3/4", "3/4", t, env)
+	checkExprResultTest("-3/4", "-3/4", t, env)
+	checkExprResultTest("6/8", "3/4", t, env)
+	checkExprResultTest("4/2", "2", t, env)
+	checkExprResultTest("(/ 1 3)", "1/3", t, env)
+	checkExprResultTest("(/ 6 3)", "2", t, env)
+	checkExprResultTest("(/ -6 4)", "-3/2", t, env)
+	checkExprResultTest("(/ 100000000000000000000 3)", "100000000000000000000/3", t, env)
+	checkExprResultTest("(/ 100000000000000000000 50000000000000000000)", "2", t, env)
+	checkExprResultTest("(+ 1/3 1/6)", "1/2", t, env)
+	checkExprResultTest("(+ 1/3 2/3)", "1", t, env)
+	checkExprResultTest("(+ 1/2 1)", "3/2", t, env)
+	checkExprResultTest("(- 1/2 1/3)", "1/6", t, env)
+	checkExprResultTest("(* 2/3 3/4)", "1/2", t, env)
+	checkExprResultTest("(* 2/3 3)", "2", t, env)
+	checkExprResultTest("(/ 1/2 1/4)", "2", t, env)
+	checkExprResultTest("(/ (/ 1 3) 2)", "1/6", t, env)
+	checkExprResultTest("(+ 1/2 0.25)", "0.75", t, env)
+	checkExprResultTest("(> 1/2 1/3)", "true", t, env)
+	checkExprResultTest("(<= 1/2 1/3)", "false", t, env)
+	checkExprResultTest("(< 1/3 1)", "true", t, env)
+	checkExprResultTest("(>= 1/2 0.5)", "true", t, env)
+	checkExprResultTest("(= 1/2 2/4)", "true", t, env)
+	// Sums of money stay exact.
+	checkExprResultTest("(+ 1/10 2/10)", "3/10", t, env)
+	malformedExprTest("(/ 1/2 0)", t, env)
+	malformedExprTest("1/0", t, env)
+	malformedExprTest("1/-2", t, env)
+	malformedExprTest("1/2/3", t, env)
+
+	checkExprResultTest("(numerator 6/4)", "3", t, env)
+	checkExprResultTest("(denominator 6/4)", "2", t, env)
+	checkExprResultTest("(numerator 5)", "5", t, env)
+	checkExprResultTest("(denominator 5)", "1", t, env)
+	checkExprResultTest("(numerator -1/2)", "-1", t, env)
+	checkExprResultTest("(denominator 0.5)", "2", t, env)
+	checkExprResultTest("(numerator 0.75)", "3", t, env)
+	malformedExprTest("(numerator \"1/2\")", t, env)
+
+	checkExprResultTest("(exact->inexact 1/4)", "0.25", t, env)
+	checkExprResultTest("(exact->inexact 3)", "3", t, env)
+	checkExprResultTest("(exact->inexact 100000000000000000000)", "1e+20", t, env)
+	checkExprResultTest("(inexact->exact 0.25)", "1/4", t, env)
+	checkExprResultTest("(inexact->exact 2.0)", "2", t, env)
+	checkExprResultTest("(inexact->exact 1/3)", "1/3", t, env)
+}
+
+func TestDecimals(t *testing.T) {
+	env := new(LangEnv)
+	env.Init()
+
+	checkExprResultTest("1.50m", "1.50m", t, env)
+	checkExprResultTest("-0.05m", "-0.05m", t, env)
+	checkExprResultTest(".5m", "0.5m", t, env)
+	checkExprResultTest("12m", "12m", t, env)
+	checkExprResultTest("1e3m", "1000m", t, env)
+	checkExprResultTest("1.5e-3m", "0.0015m", t, env)
+	checkExprResultTest("-2.50E+2m", "-250m", t, env)
+	malformedExprTest("1em", t, env)
+	malformedExprTest("1e100000m", t, env)
+	// Unlike floats, decimals add up the way they are written.
+	checkExprResultTest("(+ 0.1m 0.2m)", "0.3m", t, env)
+	checkExprResultTest("(+ 0.10m 0.20m)", "0.30m", t, env)
+	checkExprResultTest("(- 1m 0.01m)", "0.99m", t, env)
+	checkExprResultTest("(* 1.5m 2)", "3.0m", t, env)
+	checkExprResultTest("(* 0.1m 0.1m)", "0.01m", t, env)
+	checkExprResultTest("(+ 100000000000000000000 0.5m)", "100000000000000000000.5m", t, env)
+	checkExprResultTest("(/ 10m 4m)", "2.5m", t, env)
+	checkExprResultTest("(/ 1.00m 1m)", "1.00m", t, env)
+	checkExprResultTest("(/ 1m 3m)", "0.3333333333333333333333333333m", t, env)
+	checkExprResultTest("(/ 2m 3m)", "0.6666666666666666666666666667m", t, env)
+	// Rationals have no exact decimal value, so decimals mixed with them give
+	// rationals.
+	checkExprResultTest("(+ 0.5m 1/3)", "5/6", t, env)
+	checkExprResultTest("(+ 1m 1/3)", "4/3", t, env)
+	checkExprResultTest("(+ 1m 1/2)", "3/2", t, env)
+	// Every zero is 0m, whatever scale it was computed with.
+	checkExprResultTest("(- 0.3m 0.3m)", "0m", t, env)
+	checkExprResultTest("(* 0.00m 1.5m)", "0m", t, env)
+	checkExprResultTest("0.000m", "0m", t, env)
+	checkExprResultTest("(+ (- 0.3m 0.3m) 1.5m)", "1.5m", t, env)
+	checkExprResultTest("(+ 0.5m 0.25)", "0.75", t, env)
+	checkExprResultTest("(= 1.0m 1.00m)", "true", t, env)
+	checkExprResultTest("(= 1.0m 1.01m)", "false", t, env)
+	checkExprResultTest("(< 0.1m 0.2m)", "true", t, env)
+	checkExprResultTest("(>= 2m 2.00m)", "true", t, env)
+	checkExprResultTest("(> 1/3 0.3m)", "true", t, env)
+	checkExprResultTest("(exact->inexact 0.1m)", "0.1", t, env)
+	checkExprResultTest("(inexact->exact 0.1m)", "0.1m", t, env)
+	checkExprResultTest("(numerator 0.25m)", "1", t, env)
+	checkExprResultTest("(denominator 0.25m)", "4", t, env)
+	malformedExprTest("(/ 1m 0m)", t, env)
+	malformedExprTest("1.2.3m", t, env)
+
+	checkExprResultTest("(decimal-precision)", "28", t, env)
+	checkExprResultTest("(decimal-rounding)", "half-even", t, env)
+	checkExprResultTest("(decimal-round 2.5m)", "2m", t, env)
+	checkExprResultTest("(decimal-round 3.5m)", "4m", t, env)
+	checkExprResultTest("(decimal-round 1.005m 2)", "1.00m", t, env)
+	checkExprResultTest("(decimal-round 2.675 2)", "2.68m", t, env)
+	checkExprResultTest("(decimal-round 1/3 4)", "0.3333m", t, env)
+	checkExprResultTest("(decimal-round 5 2)", "5.00m", t, env)
+	checkExprResultTest("(decimal-round 1250m -2)", "1200m", t, env)
+	checkExprResultTest("(decimal-round 1.5m -1)", "0m", t, env)
+	checkExprResultTest("(decimal-round 0.4m -2)", "0m", t, env)
+
+	checkExprResultTest("(set-decimal-precision! 5)", "5", t, env)
+	checkExprResultTest("(/ 2m 3m)", "0.66667m", t, env)
+	checkExprResultTest("(* 99999m 11m)", "1100000m", t, env)
+	checkExprResultTest("(+ 0.1m 0.2m)", "0.3m", t, env)
+	malformedExprTest("(set-decimal-precision! 0)", t, env)
+	malformedExprTest("(set-decimal-precision! 100000000)", t, env)
+
+	roundingCases := []struct {
+		mode           string
+		pos, half, neg string
+	}{
+		{"half-even", "2m", "2m", "-2m"},
+		{"half-up", "2m", "3m", "-3m"},
+		{"half-down", "2m", "2m", "-2m"},
+		{"up", "3m", "3m", "-3m"},
+		{"down", "2m", "2m", "-2m"},
+		{"ceiling", "3m", "3m", "-2m"},
+		{"floor", "2m", "2m", "-3m"},
+	}
+	for _, c := range roundingCases {
+		checkExprResultTest(fmt.Sprintf("(set-decimal-rounding! '%s)", c.mode), c.mode, t, env)
+		checkExprResultTest("(decimal-round 2.25m)", c.pos, t, env)
+		checkExprResultTest("(decimal-round 2.5m)", c.half, t, env)
+		checkExprResultTest("(decimal-round -2.5m)", c.neg, t, env)
+	}
+	checkExprResultTest("(set-decimal-rounding! \"down\")", "down", t, env)
+	checkExprResultTest("(/ 2m 3m)", "0.66666m", t, env)
+	malformedExprTest("(set-decimal-rounding! 'sideways)", t, env)
+	malformedExprTest("(set-decimal-rounding! 1)", t, env)
+}
+
+func TestComplex(t *testing.T) {
+	env := new(LangEnv)
+	env.Init()
+
+	checkExprResultTest("3+4i", "3+4i", t, env)
+	checkExprResultTest("4i", "0+4i", t, env)
+	checkExprResultTest("-2.5-1i", "-2.5-1i", t, env)
+	checkExprResultTest("1+i", "1+1i", t, env)
+	checkExprResultTest("-2.5-i", "-2.5-1i", t, env)
+	checkExprResultTest("+i", "0+1i", t, env)
+	checkExprResultTest("-i", "0-1i", t, env)
+	checkExprResultTest("(* +i -i)", "1+0i", t, env)
+	checkExprResultTest("(type-of 1+i)", "complex", t, env)
+	checkExprResultTest("(+ 1+2i 3-1i)", "4+1i", t, env)
+	checkExprResultTest("(+ 1+2i 1)", "2+2i", t, env)
+	checkExprResultTest("(- 1+2i 1/2)", "0.5+2i", t, env)
+	checkExprResultTest("(* 1+1i 1-1i)", "2+0i", t, env)
+	checkExprResultTest("(* 1i 1i)", "-1+0i", t, env)
+	checkExprResultTest("(/ 1+2i 3+4i)", "0.44+0.08i", t, env)
+	checkExprResultTest("(= 1+2i 1+2i)", "true", t, env)
+	malformedExprTest("(/ 1i 0)", t, env)
+	malformedExprTest("(< 1i 2)", t, env)
+
+	checkExprResultTest("(real-part 3+4i)", "3", t, env)
+	checkExprResultTest("(imag-part 3+4i)", "4", t, env)
+	checkExprResultTest("(real-part 1/2)", "1/2", t, env)
+	checkExprResultTest("(imag-part 5)", "0", t, env)
+	checkExprResultTest("(magnitude 3+4i)", "5", t, env)
+	checkExprResultTest("(magnitude -5)", "5", t, env)
+	checkExprResultTest("(magnitude -1/2)", "1/2", t, env)
+	checkExprResultTest("(angle -1)", "3.141592653589793", t, env)
+	checkExprResultTest("(angle 1i)", "1.5707963267948966", t, env)
+	checkExprResultTest("(make-rectangular 1 2)", "1+2i", t, env)
+	checkExprResultTest("(make-polar 2 0)", "2+0i", t, env)
+	checkExprResultTest("(magnitude (make-polar 2 1))", "2", t, env)
+	malformedExprTest("(make-polar 1i 0)", t, env)
+	malformedExprTest("(real-part \"x\")", t, env)
+
+	checkExprResultTest("(sqrt 16)", "4", t, env)
+	checkExprResultTest("(sqrt 9/4)", "3/2", t, env)
+	checkExprResultTest("(sqrt 100000000000000000000)", "10000000000", t, env)
+	checkExprResultTest("(sqrt 2)", "1.4142135623730951", t, env)
+	checkExprResultTest("(sqrt 2.25)", "1.5", t, env)
+	checkExprResultTest("(sqrt -4)", "0+2i", t, env)
+	checkExprResultTest("(sqrt -2.25)", "0+1.5i", t, env)
+	checkExprResultTest("(sqrt -1+0i)", "0+1i", t, env)
+	checkExprResultTest("(sqrt 2m)", "1.414213562373095048801688724m", t, env)
+	checkExprResultTest("(sqrt 0.25m)", "0.5m", t, env)
+	checkExprResultTest("(sqrt 1.00m)", "1.0m", t, env)
+	checkExprResultTest("(sqrt 0m)", "0m", t, env)
+
+	// Names which look a bit like complex numbers are still names.
+	checkExprResultTest("(define i 2)", "2", t, env)
+	checkExprResultTest("(* i i)", "4", t, env)
+	checkExprResultTest("(define inf+i 3)", "3", t, env)
+	checkExprResultTest("(+ inf+i 1)", "4", t, env)
+}
+
+func TestNumericTower(t *testing.T) {
+	env := new(LangEnv)
+	env.Init()
+
+	// Results which fit in an int are ints again.
+	checkExprResultTest("(+ 100000000000000000000 1.5)", "1e+20", t, env)
+	checkExprResultTest("(- 100000000000000000000 99999999999999999999)", "1", t, env)
+	checkExprResultTest("(* 100000000000000000000 0)", "0", t, env)
+	checkExprResultTest("(+ 100000000000000000000 -100000000000000000000 5)", "5", t, env)
+
+	checkExprResultTest("(> 100000000000000000000 1)", "true", t, env)
+	checkExprResultTest("(< 100000000000000000000 100000000000000000001)", "true", t, env)
+	checkExprResultTest("(<= 100000000000000000001 100000000000000000000)", "false", t, env)
+	checkExprResultTest("(>= 100000000000000000000 100000000000000000000)", "true", t, env)
+	// Big integers are compared to floats exactly, rather than being rounded.
+	checkExprResultTest("(< 100000000000000000000 100000000000000000001.0)", "false", t, env)
+	checkExprResultTest("(> 100000000000000000001 100000000000000000000.0)", "true", t, env)
+	checkExprResultTest("(< 1/3 0.3334 1/2)", "true", t, env)
+	checkExprResultTest("(< 0.1m 0.1)", "true", t, env)
+	checkExprResultTest("(< 1 inf)", "true", t, env)
+
+	checkExprResultTest("(< 1 2 3)", "true", t, env)
+	checkExprResultTest("(< 1 3 2)", "false", t, env)
+	checkExprResultTest("(<= 1 1 2)", "true", t, env)
+	checkExprResultTest("(> 3 2 1)", "true", t, env)
+	checkExprResultTest("(> 3 1 2)", "false", t, env)
+	checkExprResultTest("(>= 3 3 3)", "true", t, env)
+	checkExprResultTest("(< \"a\" \"b\" \"c\")", "true", t, env)
+	malformedExprTest("(< 1 2 \"x\")", t, env)
+	malformedExprTest("(< \"a\" 1)", t, env)
+
+	checkExprResultTest("(= 1 1.0)", "true", t, env)
+	checkExprResultTest("(= 1 1.0 1m 1+0i)", "true", t, env)
+	checkExprResultTest("(= 1/2 0.5 0.5m)", "true", t, env)
+	checkExprResultTest("(= 100000000000000000000 1e20)", "true", t, env)
+	checkExprResultTest("(= 1 1 2)", "false", t, env)
+	checkExprResultTest("(= \"a\" \"a\" \"a\")", "true", t, env)
+	malformedExprTest("(= \"1\" 1)", t, env)
+
+	// Lists, vectors and hash maps are equal if their elements are.
+	checkExprResultTest("(= '(1 2 (3)) '(1.0 2 (3)))", "true", t, env)
+	checkExprResultTest("(= '(1 . 2) '(1 . 2.5))", "false", t, env)
+	checkExprResultTest("(= '(1 2) '(1 2 3))", "false", t, env)
+	checkExprResultTest("(= '(1 \"a\") '(1 a))", "false", t, env)
+	checkExprResultTest("(= [1 [2]] [1 [2.0]])", "true", t, env)
+	checkExprResultTest("(= {1 '(2)} {1 '(2.0)})", "true", t, env)
+	// Procedures are only equal to themselves.
+	checkExprResultTest("(= (lambda (x) x) (lambda (x) (* x 2)))", "false", t, env)
+	checkExprResultTest("(= (lambda (x) x) (lambda (x) x))", "false", t, env)
+	saneExprTest("(defvar same (lambda (x) x))", t, env)
+	checkExprResultTest("(= same same)", "true", t, env)
+	checkExprResultTest("(= car car)", "true", t, env)
+	checkExprResultTest("(= car cdr)", "false", t, env)
+
+	// The comparisons take any number of operands.
+	var ones, counting bytes.Buffer
+	for i := 0; i < 150; i++ {
+		fmt.Fprintf(&ones, "1 ")
+		fmt.Fprintf(&counting, "%d ", i)
+	}
+	checkExprResultTest(fmt.Sprintf("(= %s)", ones.String()), "true", t, env)
+	checkExprResultTest(fmt.Sprintf("(< %s)", counting.String()), "true", t, env)
+	checkExprResultTest(fmt.Sprintf("(>= %s)", counting.String()), "false", t, env)
+}
+
+func TestMath(t *testing.T) {
+	env := new(LangEnv)
+	env.Init()
+
+	checkExprResultTest("(quotient 7 2)", "3", t, env)
+	checkExprResultTest("(quotient -7 2)", "-3", t, env)
+	checkExprResultTest("(remainder -7 2)", "-1", t, env)
+	checkExprResultTest("(mod -7 2)", "1", t, env)
+	checkExprResultTest("(mod 7 -2)", "-1", t, env)
+	checkExprResultTest("(mod 7.0 2)", "1", t, env)
+	checkExprResultTest("(mod -100000000000000000000 7)", "5", t, env)
+	checkExprResultTest("(quotient -9223372036854775808 -1)", "9223372036854775808", t, env)
+	malformedExprTest("(quotient 1 0)", t, env)
+	malformedExprTest("(mod 7.5 2)", t, env)
+	checkExprResultTest("(mod -7m 2)", "1m", t, env)
+	checkExprResultTest("(quotient 7.0m 2)", "3m", t, env)
+	checkExprResultTest("(remainder 100000000000000000000m 7)", "2m", t, env)
+	malformedExprTest("(quotient 1 0m)", t, env)
+	malformedExprTest("(mod 1i 2)", t, env)
+	for _, expr := range []string{"(mod 1/2 2)", "(quotient 4 3/2)", "(remainder 7.5m 2)"} {
+		result := Eval(expr, env)
+		var typeErr *TypeError
+		if !errors.As(result.Err, &typeErr) {
+			t.Errorf("Expected %s to be a TypeError, got %v", expr, result.Err)
+		}
+	}
+	malformedExprTest("(remainder 1/2 2)", t, env)
+
+	checkExprResultTest("(abs -5)", "5", t, env)
+	checkExprResultTest("(abs -1/2)", "1/2", t, env)
+	checkExprResultTest("(abs -9223372036854775808)", "9223372036854775808", t, env)
+	checkExprResultTest("(abs -1.5m)", "1.5m", t, env)
+	checkExprResultTest("(min 3 1 2)", "1", t, env)
+	checkExprResultTest("(max 1/2 1/3)", "1/2", t, env)
+	// A float makes the result inexact.
+	checkExprResultTest("(max 3 2.5)", "3", t, env)
+	checkExprResultTest("(min 100000000000000000000 1e21)", "1e+20", t, env)
+	checkExprResultTest("(min 100000000000000000000 200000000000000000000)", "100000000000000000000", t, env)
+	malformedExprTest("(min 1i 2)", t, env)
+
+	checkExprResultTest("(expt 2 100)", "1267650600228229401496703205376", t, env)
+	checkExprResultTest("(expt 2 -2)", "1/4", t, env)
+	checkExprResultTest("(expt 2/3 3)", "8/27", t, env)
+	checkExprResultTest("(expt 1.1m 2)", "1.21m", t, env)
+	checkExprResultTest("(expt 2m -1)", "0.5m", t, env)
+	checkExprResultTest("(expt 2.0 0.5)", "1.4142135623730951", t, env)
+	checkExprResultTest("(expt 4 1/2)", "2", t, env)
+	checkExprResultTest("(expt 1i 2)", "-1+0i", t, env)
+	checkExprResultTest("(expt -8 1/3)", "1+1.732050807568877i", t, env)
+	malformedExprTest("(expt 0 -1)", t, env)
+	// Exact powers too large to hold are errors, rather than running out of
+	// memory.
+	malformedExprTest("(expt 2 1000000000000)", t, env)
+	malformedExprTest("(expt 1/3 -1000000000000)", t, env)
+	malformedExprTest("(expt 0.1m 1000000000000)", t, env)
+	checkExprResultTest("(expt -1 1000000000001)", "-1", t, env)
+	checkExprResultTest("(string-length (number->string (expt 2 1000000)))", "301030", t, env)
+
+	checkExprResultTest("(exact-integer-sqrt 17)", "(4 1)", t, env)
+	checkExprResultTest("(exact-integer-sqrt 100000000000000000000)", "(10000000000 0)", t, env)
+	malformedExprTest("(exact-integer-sqrt -1)", t, env)
+	malformedExprTest("(exact-integer-sqrt 2.0)", t, env)
+
+	checkExprResultTest("(floor -7/2)", "-4", t, env)
+	checkExprResultTest("(ceiling -7/2)", "-3", t, env)
+	checkExprResultTest("(round 7/2)", "4", t, env)
+	checkExprResultTest("(round 5/2)", "2", t, env)
+	checkExprResultTest("(truncate -7/2)", "-3", t, env)
+	checkExprResultTest("(floor 2.5)", "2", t, env)
+	checkExprResultTest("(round 2.5)", "2", t, env)
+	checkExprResultTest("(round -3.5)", "-4", t, env)
+	checkExprResultTest("(truncate -2.7)", "-2", t, env)
+	checkExprResultTest("(floor 2.7m)", "2m", t, env)
+	checkExprResultTest("(ceiling 2.1m)", "3m", t, env)
+	checkExprResultTest("(floor 5)", "5", t, env)
+
+	checkExprResultTest("(exp 0)", "1", t, env)
+	checkExprResultTest("(exp 1)", "2.718281828459045", t, env)
+	checkExprResultTest("(log 1)", "0", t, env)
+	checkExprResultTest("(log 100 10)", "2", t, env)
+	checkExprResultTest("(log -1)", "0+3.141592653589793i", t, env)
+	checkExprResultTest("(sin 0)", "0", t, env)
+	checkExprResultTest("(cos 0)", "1", t, env)
+	checkExprResultTest("(tan 0)", "0", t, env)
+	checkExprResultTest("(asin 1)", "1.5707963267948966", t, env)
+	checkExprResultTest("(acos 1)", "0", t, env)
+	checkExprResultTest("(atan 1)", "0.7853981633974483", t, env)
+	checkExprResultTest("(atan 1 -1)", "2.356194490192345", t, env)
+	checkExprResultTest("(asin 2)", "1.5707963267948966+1.3169578969248164i", t, env)
+	malformedExprTest("(sin \"x\")", t, env)
+	malformedExprTest("(atan 1i 1)", t, env)
+
+	checkExprResultTest("(gcd 12 18)", "6", t, env)
+	checkExprResultTest("(gcd -12 100000000000000000000)", "4", t, env)
+	checkExprResultTest("(gcd)", "0", t, env)
+	checkExprResultTest("(lcm 4 6)", "12", t, env)
+	checkExprResultTest("(lcm 4 -6 10)", "60", t, env)
+	checkExprResultTest("(lcm 0 5)", "0", t, env)
+	checkExprResultTest("(lcm)", "1", t, env)
+	malformedExprTest("(gcd 1.5 2)", t, env)
+
+	// min, max, gcd and lcm take any number of operands.
+	var counting bytes.Buffer
+	for i := 1; i <= 150; i++ {
+		fmt.Fprintf(&counting, "%d ", i)
+	}
+	checkExprResultTest(fmt.Sprintf("(min %s)", counting.String()), "1", t, env)
+	checkExprResultTest(fmt.Sprintf("(max %s)", counting.String()), "150", t, env)
+	checkExprResultTest(fmt.Sprintf("(gcd %s)", counting.String()), "1", t, env)
+	checkExprResultTest(fmt.Sprintf("(= (lcm %s) (lcm %s))", counting.String(), counting.String()), "true", t, env)
+}
+
+func TestStringLibrary(t *testing.T) {
+	env := new(LangEnv)
+	env.Init()
+
+	checkExprResultTest("(string-length \"héllo\")", "5", t, env)
+	checkExprResultTest("(substring \"hello\" 1 3)", "\"el\"", t, env)
+	checkExprResultTest("(substring \"hello\" 2)", "\"llo\"", t, env)
+	checkExprResultTest("(string-append \"foo\" \"bar\" \"baz\")", "\"foobarbaz\"", t, env)
+	checkExprResultTest("(string-append)", "\"\"", t, env)
+	var parts bytes.Buffer
+	for i := 0; i < 101; i++ {
+		parts.WriteString("\"a\" ")
+	}
+	checkExprResultTest(fmt.Sprintf("(string-length (string-append %s))", parts.String()), "101", t, env)
+	checkExprResultTest("(string-upcase \"Hello\")", "\"HELLO\"", t, env)
+	checkExprResultTest("(string-downcase \"Hello\")", "\"hello\"", t, env)
+	checkExprResultTest("(string-split \"a,b,c\" \",\")", "(\"a\" \"b\" \"c\")", t, env)
+	checkExprResultTest("(string-split \"  a  b \")", "(\"a\" \"b\")", t, env)
+	checkExprResultTest("(string-join '(\"a\" \"b\" \"c\") \"-\")", "\"a-b-c\"", t, env)
+	checkExprResultTest("(string-join '(\"a\" \"b\"))", "\"a b\"", t, env)
+	checkExprResultTest("(string-index \"héllo\" \"l\")", "2", t, env)
+	checkExprResultTest("(string-index \"hello\" \"z\")", "false", t, env)
+	checkExprResultTest("(string-replace \"aaa\" \"a\" \"b\")", "\"bbb\"", t, env)
+	checkExprResultTest("(string-trim \"  hi  \")", "\"hi\"", t, env)
+	malformedExprTest("(substring \"hello\" 3 10)", t, env)
+	malformedExprTest("(substring \"hello\" 3 1)", t, env)
+	malformedExprTest("(string-length 1)", t, env)
+	malformedExprTest("(string-append \"a\" 1)", t, env)
+
+	checkExprResultTest("(string->number \"42\")", "42", t, env)
+	checkExprResultTest("(string->number \"1/2\")", "1/2", t, env)
+	checkExprResultTest("(string->number \"2.5\")", "2.5", t, env)
+	checkExprResultTest("(string->number \"ff\" 16)", "255", t, env)
+	checkExprResultTest("(string->number \"abc\")", "false", t, env)
+	checkExprResultTest("(number->string 42)", "\"42\"", t, env)
+	checkExprResultTest("(number->string 255 16)", "\"ff\"", t, env)
+	checkExprResultTest("(number->string 1/2)", "\"1/2\"", t, env)
+	malformedExprTest("(number->string 1.5 2)", t, env)
+
+	checkExprResultTest("(format \"~a + ~a = ~a\" 1 2 3)", "\"1 + 2 = 3\"", t, env)
+	var directives, args bytes.Buffer
+	for i := 0; i < 150; i++ {
+		directives.WriteString("~a")
+		args.WriteString("1 ")
+	}
+	checkExprResultTest(fmt.Sprintf("(string-length (format \"%s\" %s))", directives.String(), args.String()), "150", t, env)
+	checkExprResultTest("(format \"~a and ~s\" \"x\" \"x\")", "\"x and \\\"x\\\"\"", t, env)
+	checkExprResultTest("(format \"~b ~o ~x\" 5 8 255)", "\"101 10 ff\"", t, env)
+	checkExprResultTest("(format \"100~~\")", "\"100~\"", t, env)
+	malformedExprTest("(format \"~a ~a\" 1)", t, env)
+	malformedExprTest("(format \"~a\" 1 2)", t, env)
+	malformedExprTest("(format \"~q\" 1)", t, env)
+	malformedExprTest("(format \"~d\" \"x\")", t, env)
+
+	var out bytes.Buffer
+	env.SetOutput(&out)
+	Eval("(display \"hi\")", env)
+	Eval("(newline)", env)
+	Eval("(write \"hi\")", env)
+	Eval("(display '(\"a\" 1))", env)
+	if out.String() != "hi\n\"hi\"(a 1)" {
+		t.Errorf("Expected the printed output to be %q, got %q", "hi\n\"hi\"(a 1)", out.String())
+	}
+
+	// The output forms have no value to print, so a script which uses them
+	// only prints what they print.
+	for _, query := range []string{"(display \"hi\")", "(write 1)", "(newline)"} {
+		if result := Eval(query, env); len(result.ValStr) > 0 || len(result.ErrStr) > 0 {
+			t.Errorf("Expected %s to have no value, got %q. Err: %s", query, result.ValStr, result.ErrStr)
+		}
+	}
+	checkExprResultTest("(type-of (newline))", "void", t, env)
+	checkExprResultTest("(list (display \"\"))", "(#<void>)", t, env)
+}
+
+func TestChars(t *testing.T) {
+	env := new(LangEnv)
+	env.Init()
+
+	checkExprResultTest("#\\a", "#\\a", t, env)
+	checkExprResultTest("#\\λ", "#\\λ", t, env)
+	checkExprResultTest("#\\x3bb", "#\\λ", t, env)
+	checkExprResultTest("#\\space", "#\\space", t, env)
+	checkExprResultTest("(char->integer #\\ )", "32", t, env)
+	checkExprResultTest("'(#\\( #\\) #\\;)", "(#\\( #\\) #\\;)", t, env)
+	checkExprResultTest("(integer->char 7)", "#\\alarm", t, env)
+	checkExprResultTest("(integer->char 1)", "#\\x1", t, env)
+	malformedExprTest("#\\foo", t, env)
+	malformedExprTest("#\\xd800", t, env)
+
+	checkExprResultTest("(char->integer #\\λ)", "955", t, env)
+	checkExprResultTest("(integer->char 955)", "#\\λ", t, env)
+	malformedExprTest("(integer->char -1)", t, env)
+	malformedExprTest("(char->integer \"a\")", t, env)
+	checkExprResultTest("(char-alphabetic? #\\é)", "true", t, env)
+	checkExprResultTest("(char-alphabetic? #\\1)", "false", t, env)
+	checkExprResultTest("(char-numeric? #\\1)", "true", t, env)
+	checkExprResultTest("(char-whitespace? #\\tab)", "true", t, env)
+	checkExprResultTest("(char-upper-case? #\\Ä)", "true", t, env)
+	checkExprResultTest("(char-lower-case? #\\Ä)", "false", t, env)
+	checkExprResultTest("(char-upcase #\\ä)", "#\\Ä", t, env)
+	checkExprResultTest("(char-downcase #\\Σ)", "#\\σ", t, env)
+	checkExprResultTest("(char-foldcase #\\ς)", "#\\σ", t, env)
+
+	checkExprResultTest("(string-length \"naïve\")", "5", t, env)
+	checkExprResultTest("(string-ref \"naïve\" 2)", "#\\ï", t, env)
+	malformedExprTest("(string-ref \"naïve\" 5)", t, env)
+	checkExprResultTest("(string->list \"añb\")", "(#\\a #\\ñ #\\b)", t, env)
+	checkExprResultTest("(list->string '(#\\a #\\ñ #\\b))", "\"añb\"", t, env)
+	malformedExprTest("(list->string '(1 2))", t, env)
+	checkExprResultTest("(string-foldcase \"Straße\")", "\"strasse\"", t, env)
+	checkExprResultTest("(string-upcase \"Straße\")", "\"STRASSE\"", t, env)
+	checkExprResultTest("(string-upcase \"ﬁx ẞ\")", "\"FIX ẞ\"", t, env)
+
+	checkExprResultTest("(= #\\a #\\a)", "true", t, env)
+	checkExprResultTest("(< #\\a #\\b #\\c)", "true", t, env)
+	checkExprResultTest("(> #\\a #\\B)", "true", t, env)
+	malformedExprTest("(< #\\a \"b\")", t, env)
+	checkExprResultTest("(< \"apple\" \"Banana\")", "true", t, env)
+	checkExprResultTest("(< \"élan\" \"ÉTÉ\")", "true", t, env)
+	// Accents count for less than the letters they are on, and more than case.
+	checkExprResultTest("(< \"é\" \"f\")", "true", t, env)
+	checkExprResultTest("(< \"cote\" \"côte\" \"coter\")", "true", t, env)
+	checkExprResultTest("(< \"Resume\" \"resume\" \"résumé\")", "true", t, env)
+	checkExprResultTest("(< \"B\" \"b\")", "true", t, env)
+	// Accents are taken off letters of any script, and off letters which are
+	// written decomposed.
+	checkExprResultTest("(< \"αβ\" \"άγ\")", "true", t, env)
+	checkExprResultTest("(< \"йа\" \"иб\")", "true", t, env)
+	checkExprResultTest("(< \"ệa\" \"eb\")", "true", t, env)
+	checkExprResultTest("(< \"e\\u0301a\" \"eb\")", "true", t, env)
+	checkExprResultTest("(< \"e\\u0301\" \"é\" \"f\")", "true", t, env)
+	checkExprResultTest("(< \"b\" \"b\")", "false", t, env)
+	checkExprResultTest("(string-ci=? \"Straße\" \"STRASSE\" \"strasse\")", "true", t, env)
+	checkExprResultTest("(string-ci=? \"ΣΑΣ\" \"σας\")", "true", t, env)
+	checkExprResultTest("(string-ci<? \"apple\" \"BANANA\")", "true", t, env)
+	checkExprResultTest("(string-ci>=? \"b\" \"B\" \"a\")", "true", t, env)
+	checkExprResultTest("(char-ci=? #\\a #\\A)", "true", t, env)
+	checkExprResultTest("(char-ci<? #\\a #\\B)", "true", t, env)
+	malformedExprTest("(char-ci=? #\\a \"a\")", t, env)
+
+	var strs, chars bytes.Buffer
+	fmt.Fprintf(&strs, "(string-ci=?")
+	fmt.Fprintf(&chars, "(char-ci<=?")
+	for i := 0; i < 150; i++ {
+		fmt.Fprintf(&strs, " \"A\" \"a\"")
+		fmt.Fprintf(&chars, " #\\a #\\B")
+	}
+	fmt.Fprintf(&strs, ")")
+	fmt.Fprintf(&chars, ")")
+	checkExprResultTest(strs.String(), "true", t, env)
+	checkExprResultTest(chars.String(), "false", t, env)
+
+	var out bytes.Buffer
+	env.SetOutput(&out)
+	Eval("(display (list #\\a \"b\"))", env)
+	Eval("(write #\\a)", env)
+	if out.String() != "(a b)#\\a" {
+		t.Errorf("Expected the printed output to be %q, got %q", "(a b)#\\a", out.String())
+	}
+}
+
+func TestHashMaps(t *testing.T) {
+	env := new(LangEnv)
+	env.Init()
+
+	checkExprResultTest("{}", "{}", t, env)
+	checkExprResultTest("(hash)", "{}", t, env)
+	checkExprResultTest("{\"b\" 2 \"a\" 1}", "{\"a\" 1 \"b\" 2}", t, env)
+	checkExprResultTest("{10 'x 9 'y 1/2 'z}", "{1/2 z 9 y 10 x}", t, env)
+	checkExprResultTest("{1 'a 1 'b}", "{1 b}", t, env)
+	checkExprResultTest("{(+ 1 1) (* 2 2)}", "{2 4}", t, env)
+	checkExprResultTest("{{1 2} #;(ignored) 3}", "{{1 2} 3}", t, env)
+	malformedExprTest("{1 2 3}", t, env)
+	malformedExprTest("{1 2", t, env)
+	malformedExprTest("{1 2)", t, env)
+	malformedExprTest("(1 2}", t, env)
+	malformedExprTest("{(lambda (x) x) 1}", t, env)
+
+	// Literals can have any number of entries.
+	var big bytes.Buffer
+	for i := 0; i < 60; i++ {
+		fmt.Fprintf(&big, "%d %d ", i, i*i)
+	}
+	checkExprResultTest(fmt.Sprintf("(hash-count {%s})", big.String()), "60", t, env)
+
+	saneExprTest("(defvar m {'name \"Zoë\" 'age 30})", t, env)
+	checkExprResultTest("(hash-ref m 'name)", "\"Zoë\"", t, env)
+	checkExprResultTest("(hash-ref m 'city \"Oslo\")", "\"Oslo\"", t, env)
+	malformedExprTest("(hash-ref m 'city)", t, env)
+	malformedExprTest("(hash-ref '(1 2) 1)", t, env)
+	checkExprResultTest("(hash-set m 'city \"Oslo\")", "{age 30 city \"Oslo\" name \"Zoë\"}", t, env)
+	checkExprResultTest("(hash-set m 'age 31)", "{age 31 name \"Zoë\"}", t, env)
+	checkExprResultTest("(hash-remove m 'age)", "{name \"Zoë\"}", t, env)
+	checkExprResultTest("(hash-remove m 'city)", "{age 30 name \"Zoë\"}", t, env)
+	// The functional updates leave the original alone.
+	checkExprResultTest("m", "{age 30 name \"Zoë\"}", t, env)
+	checkExprResultTest("(hash-count m)", "2", t, env)
+	checkExprResultTest("(hash-has-key? m 'age)", "true", t, env)
+	checkExprResultTest("(hash-has-key? m 'city)", "false", t, env)
+	checkExprResultTest("(hash-keys m)", "(age name)", t, env)
+	checkExprResultTest("(hash-values m)", "(30 \"Zoë\")", t, env)
+	checkExprResultTest("(hash->list m)", "((age . 30) (name . \"Zoë\"))", t, env)
+
+	// Keys are compared structurally, and numbers which are = are the same key.
+	checkExprResultTest("(hash-ref {'(1 \"a\") 'found} (list 1 \"a\"))", "found", t, env)
+	checkExprResultTest("(hash-ref {{1 2} 'found} (hash 1 2))", "found", t, env)
+	checkExprResultTest("(hash-ref {1 'found} 2/2)", "found", t, env)
+	checkExprResultTest("(hash-ref {1 'found} 1.0)", "found", t, env)
+	checkExprResultTest("(hash-ref {1.0 'found} 1)", "found", t, env)
+	checkExprResultTest("(hash-ref {1/2 'found} 0.5)", "found", t, env)
+	checkExprResultTest("(hash-ref {2 'found} 2+0i)", "found", t, env)
+	checkExprResultTest("(hash-ref {1/10 'found} 0.1 'missing)", "missing", t, env)
+	checkExprResultTest("(hash-count {1 'a 1.0 'b})", "1", t, env)
+	checkExprResultTest("(hash-ref {#\\a 'found} #\\a)", "found", t, env)
+
+	checkExprResultTest("(= {1 2 3 4} {3 4 1 2})", "true", t, env)
+	checkExprResultTest("(= {1 2} {1 3})", "false", t, env)
+	checkExprResultTest("(= {} (hash-remove {1 2} 1))", "true", t, env)
+	// Maps which are = have the same keys, so lookups agree with =.
+	checkExprResultTest("(= {1.0 \"a\"} {1 \"a\"})", "true", t, env)
+	checkExprResultTest("(= {0.1 \"a\"} {1/10 \"a\"})", "false", t, env)
+
+	saneExprTest("(defvar total 0)", t, env)
+	checkExprResultTest("(type-of (hash-for-each {1 10 2 20} (lambda (k v) (set! total (+ total k v)))))", "void", t, env)
+	checkExprResultTest("total", "33", t, env)
+	malformedExprTest("(hash-for-each {1 10} (lambda (k) k))", t, env)
+	// Procedures are called as they are, and symbols are not procedures.
+	checkExprResultTest("(type-of (hash-for-each {1 10} cons))", "void", t, env)
+	malformedExprTest("(hash-for-each {1 10} 'cons)", t, env)
+	malformedExprTest("(hash-for-each {1 10} \"cons\")", t, env)
+
+	var out bytes.Buffer
+	env.SetOutput(&out)
+	Eval("(hash-for-each {\"b\" 2 \"a\" 1} (lambda (k v) (display k) (display v)))", env)
+	Eval("(display {\"a\" #\\b})", env)
+	if out.String() != "a1b2{a b}" {
+		t.Errorf("Expected the printed output to be %q, got %q", "a1b2{a b}", out.String())
+	}
+}
+
+func TestVectors(t *testing.T) {
+	env := new(LangEnv)
+	env.Init()
+
+	checkExprResultTest("#(1 2 3)", "#(1 2 3)", t, env)
+	checkExprResultTest("[1 (+ 1 1) \"x\"]", "#(1 2 \"x\")", t, env)
+	checkExprResultTest("[]", "#()", t, env)
+	checkExprResultTest("(vector [1] #(2 #;3))", "#(#(1) #(2))", t, env)
+	malformedExprTest("[1 2)", t, env)
+	malformedExprTest("#(1 2]", t, env)
+	malformedExprTest("[1 2", t, env)
+	malformedExprTest("]", t, env)
+
+	// Literals can have any number of elements.
+	var big bytes.Buffer
+	for i := 0; i <= 100; i++ {
+		fmt.Fprintf(&big, "%d ", i)
+	}
+	checkExprResultTest(fmt.Sprintf("(vector-length [%s])", big.String()), "101", t, env)
+	checkExprResultTest(fmt.Sprintf("(vector-ref #(%s) 100)", big.String()), "100", t, env)
+
+	saneExprTest("(defvar v (make-vector 3))", t, env)
+	checkExprResultTest("v", "#(0 0 0)", t, env)
+	checkExprResultTest("(make-vector 2 'x)", "#(x x)", t, env)
+	malformedExprTest("(make-vector -1)", t, env)
+	checkExprResultTest("(vector-set! v 0 'a)", "a", t, env)
+	checkExprResultTest("(vector-ref v 0)", "a", t, env)
+	checkExprResultTest("v", "#(a 0 0)", t, env)
+	malformedExprTest("(vector-ref v 3)", t, env)
+	malformedExprTest("(vector-set! v -1 0)", t, env)
+	malformedExprTest("(vector-ref '(1 2) 0)", t, env)
+	checkExprResultTest("(vector-length v)", "3", t, env)
+	checkExprResultTest("(vector-length [])", "0", t, env)
+	checkExprResultTest("(vector-fill! v 7)", "#(7 7 7)", t, env)
+	checkExprResultTest("v", "#(7 7 7)", t, env)
+	// A vector can not end up inside itself.
+	malformedExprTest("(vector-set! v 0 v)", t, env)
+	malformedExprTest("(vector-fill! v (list {1 [v]}))", t, env)
+
+	// Vectors are shared, not copied, when they are passed around.
+	checkExprResultTest("(let ((w v)) (vector-set! w 1 'b) v)", "#(7 b 7)", t, env)
+
+	checkExprResultTest("(vector-slice #(1 2 3 4) 1 3)", "#(2 3)", t, env)
+	checkExprResultTest("(vector-slice #(1 2 3 4) 4)", "#()", t, env)
+	malformedExprTest("(vector-slice #(1 2 3 4) 3 1)", t, env)
+	malformedExprTest("(vector-slice #(1 2 3 4) 0 5)", t, env)
+	checkExprResultTest("(let ((s (vector-slice v 0 1))) (vector-set! s 0 'c) v)", "#(7 b 7)", t, env)
+
+	checkExprResultTest("(vector->list [1 2])", "(1 2)", t, env)
+	checkExprResultTest("(vector->list [])", "()", t, env)
+	checkExprResultTest("(list->vector '(1 2))", "#(1 2)", t, env)
+	checkExprResultTest("(list->vector '())", "#()", t, env)
+	malformedExprTest("(list->vector '(1 . 2))", t, env)
+	malformedExprTest("(list->vector 1)", t, env)
+
+	checkExprResultTest("(= [1 2] #(1 2))", "true", t, env)
+	malformedExprTest("(= [1 2] '(1 2))", t, env)
+	malformedExprTest("{[1] 2}", t, env)
+
+	// Indexed algorithms, like a table of Fibonacci numbers.
+	saneExprTest(`(define (fib-table n)
+		(let ((table (make-vector (+ n 1) 1)))
+			(letrec ((fill (lambda (i)
+				(when (<= i n)
+					(vector-set! table i (+ (vector-ref table (- i 1)) (vector-ref table (- i 2))))
+					(fill (+ i 1))))))
+				(fill 2)
+				table)))`, t, env)
+	checkExprResultTest("(fib-table 6)", "#(1 1 2 3 5 8 13)", t, env)
+
+	var out bytes.Buffer
+	env.SetOutput(&out)
+	Eval("(display [\"a\" #\\b])", env)
+	if out.String() != "#(a b)" {
+		t.Errorf("Expected the printed output to be %q, got %q", "#(a b)", out.String())
+	}
+}
+
+func TestTypePredicates(t *testing.T) {
+	env := new(LangEnv)
+	env.Init()
+
+	typesOf := map[string]string{
+		"1":                     "integer",
+		"100000000000000000000": "integer",
+		"1/2":                   "rational",
+		"1.5m":                  "decimal",
+		"1.5":                   "float",
+		"1+2i":                  "complex",
+		"\"s\"":                 "string",
+		"#\\a":                  "char",
+		"true":                  "boolean",
+		"'a":                    "symbol",
+		"'(1)":                  "pair",
+		"'()":                   "null",
+		"car":                   "procedure",
+		"(lambda (x) x)":        "procedure",
+		"if":                    "syntax",
+		"{}":                    "hash",
+		"[]":                    "vector",
+	}
+	for expr, typ := range typesOf {
+		checkExprResultTest("(type-of "+expr+")", typ, t, env)
+	}
+	checkExprResultTest("(= (type-of 1) 'integer)", "true", t, env)
+
+	checkExprResultTest("(number? 1+2i)", "true", t, env)
+	checkExprResultTest("(number? \"1\")", "false", t, env)
+	checkExprResultTest("(complex? 1)", "true", t, env)
+	checkExprResultTest("(real? 1.5m)", "true", t, env)
+	checkExprResultTest("(real? 1+2i)", "false", t, env)
+	checkExprResultTest("(rational? 1.5)", "true", t, env)
+	checkExprResultTest("(rational? (* 1e308 10))", "false", t, env)
+	checkExprResultTest("(integer? 2)", "true", t, env)
+	checkExprResultTest("(integer? 2.0)", "true", t, env)
+	checkExprResultTest("(integer? 2.00m)", "true", t, env)
+	checkExprResultTest("(integer? 2.5m)", "false", t, env)
+	checkExprResultTest("(integer? 4/2)", "true", t, env)
+	checkExprResultTest("(integer? \"2\")", "false", t, env)
+	checkExprResultTest("(exact-integer? 100000000000000000000)", "true", t, env)
+	checkExprResultTest("(exact-integer? 2.0)", "false", t, env)
+	checkExprResultTest("(float? 2.0)", "true", t, env)
+	checkExprResultTest("(float? 2)", "false", t, env)
+	checkExprResultTest("(decimal? 2.0m)", "true", t, env)
+	checkExprResultTest("(string? \"s\")", "true", t, env)
+	checkExprResultTest("(string? 's)", "false", t, env)
+	checkExprResultTest("(char? #\\s)", "true", t, env)
+	checkExprResultTest("(boolean? false)", "true", t, env)
+	checkExprResultTest("(boolean? '())", "false", t, env)
+	checkExprResultTest("(symbol? 'a)", "true", t, env)
+	checkExprResultTest("(symbol? \"a\")", "false", t, env)
+	checkExprResultTest("(hash? {1 2})", "true", t, env)
+	checkExprResultTest("(vector? [1 2])", "true", t, env)
+	checkExprResultTest("(vector? '(1 2))", "false", t, env)
+
+	saneExprTest("(defun f (x) x)", t, env)
+	saneExprTest("(defmacro m (x) x)", t, env)
+	checkExprResultTest("(procedure? f)", "true", t, env)
+	checkExprResultTest("(procedure? car)", "true", t, env)
+	checkExprResultTest("(procedure? (lambda (x) x))", "true", t, env)
+	checkExprResultTest("(procedure? if)", "false", t, env)
+	checkExprResultTest("(procedure? m)", "false", t, env)
+	checkExprResultTest("(procedure? 'f)", "false", t, env)
+
+	checkExprResultTest("(exact? 1)", "true", t, env)
+	checkExprResultTest("(exact? 1/2)", "true", t, env)
+	checkExprResultTest("(exact? 1.5m)", "true", t, env)
+	checkExprResultTest("(exact? 1.5)", "false", t, env)
+	checkExprResultTest("(inexact? 1.5)", "true", t, env)
+	checkExprResultTest("(inexact? 1+2i)", "true", t, env)
+	checkExprResultTest("(inexact? 1)", "false", t, env)
+	malformedExprTest("(exact? \"a\")", t, env)
+	malformedExprTest("(inexact? 'a)", t, env)
+
+	// Library code can check its inputs, instead of failing in an operator.
+	saneExprTest(`(define (safe-add x y)
+		(if (and (number? x) (number? y)) (+ x y) 'not-a-number))`, t, env)
+	checkExprResultTest("(safe-add 1 2)", "3", t, env)
+	checkExprResultTest("(safe-add 1 \"2\")", "not-a-number", t, env)
+}
+
+func TestConditionals(t *testing.T) {
+	env := new(LangEnv)
+	env.Init()
+
+	checkExprResultTest("(if true 1 2)", "1", t, env)
+	checkExprResultTest("(if false 1 2)", "2", t, env)
+	checkExprResultTest("(if (> 2 1) \"yes\" \"no\")", "\"yes\"", t, env)
+	checkExprResultTest("(if false 1)", "()", t, env)
+	// Only false is false, everything else is true.
+	checkExprResultTest("(if 0 1 2)", "1", t, env)
+	checkExprResultTest("(if '() 1 2)", "1", t, env)
+	// The branch which is not taken is not evaluated.
+	checkExprResultTest("(if true 1 (/ 1 0))", "1", t, env)
+	malformedExprTest("(if true)", t, env)
+	malformedExprTest("(if true 1 2 3)", t, env)
+
+	checkExprResultTest("(when true 1 2 3)", "3", t, env)
+	checkExprResultTest("(when false 1 2 3)", "()", t, env)
+	checkExprResultTest("(unless false 1 2)", "2", t, env)
+	checkExprResultTest("(unless true (/ 1 0))", "()", t, env)
+	malformedExprTest("(when true)", t, env)
+
+	checkExprResultTest("(cond (1 2))", "2", t, env)
+	checkExprResultTest("(cond (false 1) (false 2))", "()", t, env)
+	checkExprResultTest("(cond (false 1) (else 2))", "2", t, env)
+	checkExprResultTest("(cond (false 1) (else 2 3))", "3", t, env)
+	checkExprResultTest("(cond ((= 1 1) 2 3) (else 4))", "3", t, env)
+	checkExprResultTest("(cond (false 1) (5))", "5", t, env)
+	checkExprResultTest("(cond ((car '(4 5)) => (lambda (x) (* x 2))) (else 0))", "8", t, env)
+	checkExprResultTest("(cond ('(4 5) => cdr) (else 0))", "(5)", t, env)
+	malformedExprTest("(cond (else 1) (true 2))", t, env)
+	malformedExprTest("(cond (else))", t, env)
+	malformedExprTest("(cond (true =>))", t, env)
+	malformedExprTest("(cond (true => car 1))", t, env)
+	malformedExprTest("(cond (1 => 2))", t, env)
+
+	saneExprTest("(defvar x 5)", t, env)
+	checkExprResultTest("(cond (x x))", "5", t, env)
+	saneExprTest("(defun sign (n) (cond ((< n 0) -1) ((= n 0) 0) (else 1)))", t, env)
+	checkExprResultTest("(sign -3)", "-1", t, env)
+	checkExprResultTest("(sign 0)", "0", t, env)
+	checkExprResultTest("(sign 3)", "1", t, env)
+	saneExprTest("(defun count-down (n) (if (= n 0) \"done\" (count-down (- n 1))))", t, env)
+	checkExprResultTest("(count-down 150000)", "\"done\"", t, env)
+}
+
+func TestSequencing(t *testing.T) {
+	env := new(LangEnv)
+	env.Init()
+
+	checkExprResultTest("(begin 1 2 3)", "3", t, env)
+	checkExprResultTest("(progn 1 2 3)", "3", t, env)
+	checkExprResultTest("(begin (defvar a 1) (defvar b 2) (+ a b))", "3", t, env)
+	checkExprResultTest("(+ a b)", "3", t, env)
+	malformedExprTest("(begin)", t, env)
+	malformedExprTest("(begin 1 (/ 1 0) 3)", t, env)
+
+	// Methods and lambdas can have multiple expressions in their body.
+	saneExprTest("(defun sum-of-squares (x y) (defvar xx (* x x)) (defvar yy (* y y)) (+ xx yy))", t, env)
+	checkExprResultTest("(sum-of-squares 3 4)", "25", t, env)
+	malformedExprTest("xx", t, env)
+	checkExprResultTest("((lambda (x) (defvar y (+ x 1)) (* y 2)) 4)", "10", t, env)
+
+	// Bodies can have any number of expressions.
+	var body bytes.Buffer
+	for i := 0; i < 150; i++ {
+		fmt.Fprintf(&body, "%d ", i)
+	}
+	checkExprResultTest(fmt.Sprintf("(begin %s)", body.String()), "149", t, env)
+	checkExprResultTest(fmt.Sprintf("(progn %s)", body.String()), "149", t, env)
+	saneExprTest(fmt.Sprintf("(defun long-body () %s)", body.String()), t, env)
+	checkExprResultTest("(long-body)", "149", t, env)
+	checkExprResultTest(fmt.Sprintf("((lambda () %s))", body.String()), "149", t, env)
+	saneExprTest(fmt.Sprintf("(define (long-define) %s)", body.String()), t, env)
+	checkExprResultTest("(long-define)", "149", t, env)
+	for _, form := range []string{"let", "let*", "letrec"} {
+		checkExprResultTest(fmt.Sprintf("(%s ((x 1)) %s)", form, body.String()), "149", t, env)
+	}
+	checkExprResultTest(fmt.Sprintf("(when true %s)", body.String()), "149", t, env)
+	checkExprResultTest(fmt.Sprintf("(unless false %s)", body.String()), "149", t, env)
+	saneExprTest(fmt.Sprintf("(defmacro long-macro () %s)", body.String()), t, env)
+	checkExprResultTest("(long-macro)", "149", t, env)
+	var clauses bytes.Buffer
+	for i := 0; i < 150; i++ {
+		fmt.Fprintf(&clauses, "((= x %d) %d) ", i, i)
+	}
+	checkExprResultTest(fmt.Sprintf("(let ((x 149)) (cond %s))", clauses.String()), "149", t, env)
+}
+
+func TestLocalBindings(t *testing.T) {
+	env := new(LangEnv)
+	env.Init()
+
+	checkExprResultTest("(let ((x 1) (y 2)) (+ x y))", "3", t, env)
+	checkExprResultTest("(let ((x 1)) (defvar z 2) (+ x z))", "3", t, env)
+	malformedExprTest("x", t, env)
+	malformedExprTest("z", t, env)
+
+	// let evaluates its values in the enclosing environment.
+	saneExprTest("(defvar x 10)", t, env)
+	checkExprResultTest("(let ((x 1) (y x)) y)", "10", t, env)
+	checkExprResultTest("(let* ((x 1) (y x)) y)", "1", t, env)
+	checkExprResultTest("(let* ((x 1) (x (+ x 1)) (x (* x 3))) x)", "6", t, env)
+	checkExprResultTest("x", "10", t, env)
+
+	checkExprResultTest("(let ((f (lambda (n) (* n 2)))) (f 21))", "42", t, env)
+	checkExprResultTest("(letrec ((even (lambda (n) (cond ((= n 0) true) (true (odd (- n 1))))))"+
+		" (odd (lambda (n) (cond ((= n 0) false) (true (even (- n 1)))))))"+
+		" (even 10))", "true", t, env)
+	malformedExprTest("(let ((f (lambda (n) (cond ((= n 0) 0) (true (f (- n 1))))))) (f 3))", t, env)
+	checkExprResultTest("(letrec ((f (lambda (n) (cond ((= n 0) 0) (true (f (- n 1))))))) (f 3))", "0", t, env)
+
+	malformedExprTest("(let x 1)", t, env)
+	malformedExprTest("(let ((x)) x)", t, env)
+	malformedExprTest("(let ((1 2)) 3)", t, env)
+	malformedExprTest("(let ((x 1)))", t, env)
+}
+
+func TestLexicalScoping(t *testing.T) {
+	env := new(LangEnv)
+	env.Init()
+
+	// Methods see definitions made after them.
+	saneExprTest("(defun is-even (n) (cond ((= n 0) true) (true (is-odd (- n 1)))))", t, env)
+	saneExprTest("(defun is-odd (n) (cond ((= n 0) false) (true (is-even (- n 1)))))", t, env)
+	checkExprResultTest("(is-even 100)", "true", t, env)
+	checkExprResultTest("(is-odd 7)", "true", t, env)
+
+	saneExprTest("(defun get-limit () limit)", t, env)
+	malformedExprTest("(get-limit)", t, env)
+	saneExprTest("(defvar limit 5)", t, env)
+	checkExprResultTest("(get-limit)", "5", t, env)
+	saneExprTest("(defvar limit 6)", t, env)
+	checkExprResultTest("(get-limit)", "6", t, env)
+
+	// Variables are resolved where the method was defined, not where it is
+	// called from.
+	saneExprTest("(defun call-get-limit (limit) (get-limit))", t, env)
+	checkExprResultTest("(call-get-limit 100)", "6", t, env)
+	checkExprResultTest("(let ((limit 100)) (get-limit))", "6", t, env)
+
+	// Parameters shadow methods of the same name, and not the other way round.
+	saneExprTest("(defun apply-it (is-odd x) (is-odd x))", t, env)
+	checkExprResultTest("(apply-it (lambda (x) (* x 2)) 4)", "8", t, env)
+	checkExprResultTest("(is-odd 4)", "false", t, env)
+
+	// Definitions inside a method stay inside it.
+	saneExprTest("(defun local-method (x) (defun helper (y) (* y 3)) (helper x))", t, env)
+	checkExprResultTest("(local-method 2)", "6", t, env)
+	malformedExprTest("(helper 2)", t, env)
+
+	saneExprTest("(defun count-down (n) (cond ((= n 0) 0) (true (count-down (- n 1)))))", t, env)
+	checkExprResultTest("(count-down 10000)", "0", t, env)
+}
+
+func TestAssignment(t *testing.T) {
+	env := new(LangEnv)
+	env.Init()
+
+	saneExprTest("(defvar counter 0)", t, env)
+	checkExprResultTest("(set! counter (+ counter 1))", "1", t, env)
+	checkExprResultTest("counter", "1", t, env)
+
+	// set! changes the binding where it was made, and not in the method.
+	saneExprTest("(defun increment () (set! counter (+ counter 1)))", t, env)
+	saneExprTest("(increment)", t, env)
+	saneExprTest("(increment)", t, env)
+	checkExprResultTest("counter", "3", t, env)
+
+	// Closures can keep state of their own.
+	saneExprTest("(define (make-counter) (let ((n 0)) (lambda () (set! n (+ n 1)) n)))", t, env)
+	saneExprTest("(define c1 (make-counter))", t, env)
+	saneExprTest("(define c2 (make-counter))", t, env)
+	saneExprTest("(c1)", t, env)
+	checkExprResultTest("(c1)", "2", t, env)
+	checkExprResultTest("(c2)", "1", t, env)
+
+	// Local definitions shadow the outer ones, instead of changing them.
+	checkExprResultTest("(let ((x 1)) (defvar counter 10) counter)", "10", t, env)
+	checkExprResultTest("(let ((x 1)) (define counter 20) (set! counter 30) counter)", "30", t, env)
+	checkExprResultTest("counter", "3", t, env)
+	checkExprResultTest("(let ((counter 5)) (set! counter 6) counter)", "6", t, env)
+	checkExprResultTest("counter", "3", t, env)
+
+	saneExprTest("(define (sum-to n) (define total 0) (define (loop i) (when (<= i n) (set! total (+ total i)) (loop (+ i 1)))) (loop 1) total)", t, env)
+	checkExprResultTest("(sum-to 100)", "5050", t, env)
+	saneExprTest("(define f car)", t, env)
+	checkExprResultTest("(f '(1 2))", "1", t, env)
+	checkExprResultTest("(let ((g car)) (set! g cdr) (g '(1 2)))", "(2)", t, env)
+
+	malformedExprTest("(set! undefinedVar 1)", t, env)
+	malformedExprTest("(set! + 1)", t, env)
+	malformedExprTest("(set! increment 1)", t, env)
+	malformedExprTest("(set! 1 1)", t, env)
+	malformedExprTest("(set! counter)", t, env)
+	malformedExprTest("(define + 1)", t, env)
+	malformedExprTest("(define x 1 2)", t, env)
+	malformedExprTest("(define (1 x) x)", t, env)
+	malformedExprTest("(define (h 1) x)", t, env)
+	checkExprResultTest("counter", "3", t, env)
+}
+
+func TestTailCalls(t *testing.T) {
+	env := new(LangEnv)
+	env.Init()
+
+	// These loops go deeper than the recursion limit, which only applies to
+	// calls that are not in tail position.
+	saneExprTest("(defun count-up (n acc) (cond ((= n 0) acc) (true (count-up (- n 1) (+ acc 1)))))", t, env)
+	checkExprResultTest("(count-up 150000 0)", "150000", t, env)
+
+	saneExprTest("(defvar loop (lambda (n) (begin (let ((m (- n 1))) (cond ((= m 0) true) (true (loop m)))))))", t, env)
+	checkExprResultTest("(loop 150000)", "true", t, env)
+
+	saneExprTest("(defun ping (n) (cond ((= n 0) \"ping\") (true (pong (- n 1)))))", t, env)
+	saneExprTest("(defun pong (n) (cond ((= n 0) \"pong\") (true (ping (- n 1)))))", t, env)
+	checkExprResultTest("(ping 150001)", "\"pong\"", t, env)
+	saneExprTest("(defun all-even (n) (or (= n 0) (and (= n (* 2 (/ n 2))) (all-even (- n 2)))))", t, env)
+	checkExprResultTest("(all-even 150000)", "true", t, env)
+
+	saneExprTest("(defun deep (n) (cond ((= n 0) 0) (true (+ 1 (deep (- n 1))))))", t, env)
+	checkExprResultTest("(deep 1000)", "1000", t, env)
+	malformedExprTest("(deep 150000)", t, env)
+	checkExprResultTest("(deep 10)", "10", t, env)
+}
+
+func TestLists(t *testing.T) {
+	env := new(LangEnv)
+	env.Init()
+
+	checkExprResultTest("(quote (1 2 (3)))", "(1 2 (3))", t, env)
+	checkExprResultTest("'(1 2 (3))", "(1 2 (3))", t, env)
+	checkExprResultTest("'(1 \"two\" 3.5 four)", "(1 \"two\" 3.5 four)", t, env)
+	checkExprResultTest("'()", "()", t, env)
+	checkExprResultTest("'(1 . 2)", "(1 . 2)", t, env)
+	checkExprResultTest("''a", "(quote a)", t, env)
+	malformedExprTest("'", t, env)
+	malformedExprTest("(quote 1 2)", t, env)
+
+	// Symbols are values, and are not resolved as variables.
+	checkExprResultTest("'undefinedName", "undefinedName", t, env)
+	checkExprResultTest("(= 'a 'a)", "true", t, env)
+	checkExprResultTest("(= 'a 'b)", "false", t, env)
+	saneExprTest("(defvar s 'sym)", t, env)
+	checkExprResultTest("s", "sym", t, env)
+
+	checkExprResultTest("(cons 1 '(2 3))", "(1 2 3)", t, env)
+	checkExprResultTest("(cons 1 2)", "(1 . 2)", t, env)
+	checkExprResultTest("(car '(a b c))", "a", t, env)
+	checkExprResultTest("(cdr '(a b c))", "(b c)", t, env)
+	checkExprResultTest("(cdr '(a))", "()", t, env)
+	malformedExprTest("(car '())", t, env)
+	malformedExprTest("(cdr 1)", t, env)
+
+	checkExprResultTest("(list 1 (+ 1 1) 'three)", "(1 2 three)", t, env)
+	checkExprResultTest("(list)", "()", t, env)
+	checkExprResultTest("(null? '())", "true", t, env)
+	checkExprResultTest("(null? '(1))", "false", t, env)
+	checkExprResultTest("(pair? '(1))", "true", t, env)
+	checkExprResultTest("(pair? '())", "false", t, env)
+	checkExprResultTest("(length '(1 2 3))", "3", t, env)
+	checkExprResultTest("(length '())", "0", t, env)
+	malformedExprTest("(length (cons 1 2))", t, env)
+	checkExprResultTest("(append '(1 2) '() '(3) '(4 5))", "(1 2 3 4 5)", t, env)
+	checkExprResultTest("(append '(1) 2)", "(1 . 2)", t, env)
+	checkExprResultTest("(append)", "()", t, env)
+	malformedExprTest("(append 1 '(2))", t, env)
+
+	// list and append take any number of operands.
+	var operands bytes.Buffer
+	for i := 0; i < 101; i++ {
+		operands.WriteString("'(1) ")
+	}
+	checkExprResultTest(fmt.Sprintf("(length (list %s))", operands.String()), "101", t, env)
+	checkExprResultTest(fmt.Sprintf("(length (append %s))", operands.String()), "101", t, env)
+	checkExprResultTest("(reverse '(1 2 (3 4)))", "((3 4) 2 1)", t, env)
+
+	saneExprTest("(defun sum (l) (cond ((null? l) 0) (true (+ (car l) (sum (cdr l))))))", t, env)
+	checkExprResultTest("(sum '(1 2 3 4))", "10", t, env)
+	saneExprTest("(defun map (f l) (cond ((null? l) '()) (true (cons (f (car l)) (map f (cdr l))))))", t, env)
+	checkExprResultTest("(map (lambda (x) (* x x)) (list 1 2 3))", "(1 4 9)", t, env)
+}
+
+func TestRestParameters(t *testing.T) {
+	env := new(LangEnv)
+	env.Init()
+
+	checkExprResultTest("((lambda (x . rest) rest) 1 2 3)", "(2 3)", t, env)
+	checkExprResultTest("((lambda (x . rest) rest) 1)", "()", t, env)
+	checkExprResultTest("((lambda (. rest) rest) 1 2)", "(1 2)", t, env)
+	checkExprResultTest("(lambda (x . rest) rest)", "<Lambda: (x . rest)>", t, env)
+	// Procedures made by define print with their names.
+	saneExprTest("(define (tagged x . rest) rest)", t, env)
+	checkExprResultTest("tagged", "<Lambda: tagged (x . rest)>", t, env)
+	saneExprTest("(define alias tagged)", t, env)
+	checkExprResultTest("alias", "<Lambda: tagged (x . rest)>", t, env)
+	malformedExprTest("((lambda (x y . rest) rest) 1)", t, env)
+	malformedExprTest("(lambda (x . 1) x)", t, env)
+	malformedExprTest("(lambda (x . (y)) x)", t, env)
+
+	saneExprTest("(defun sum (. numbers) (cond ((null? numbers) 0) (else (+ (car numbers) (apply-sum (cdr numbers))))))", t, env)
+	saneExprTest("(defun apply-sum (numbers) (cond ((null? numbers) 0) (else (+ (car numbers) (apply-sum (cdr numbers))))))", t, env)
+	checkExprResultTest("(sum 1 2 3 4)", "10", t, env)
+	checkExprResultTest("(sum)", "0", t, env)
+	saneExprTest("(define (tag name . values) (cons name values))", t, env)
+	checkExprResultTest("(tag 'a 1 2)", "(a 1 2)", t, env)
+}
+
+func TestQuasiquote(t *testing.T) {
+	env := new(LangEnv)
+	env.Init()
+
+	saneExprTest("(defvar x 5)", t, env)
+	saneExprTest("(defvar xs '(1 2 3))", t, env)
+	checkExprResultTest("`(a b c)", "(a b c)", t, env)
+	checkExprResultTest("`(a ,x c)", "(a 5 c)", t, env)
+	checkExprResultTest("`(a (+ x 1) ,(+ x 1))", "(a (+ x 1) 6)", t, env)
+	checkExprResultTest("`(a ,@xs b)", "(a 1 2 3 b)", t, env)
+	checkExprResultTest("`(,@xs)", "(1 2 3)", t, env)
+	checkExprResultTest("`(a ,@'() b)", "(a b)", t, env)
+	checkExprResultTest("`(a . ,x)", "(a . 5)", t, env)
+	checkExprResultTest("`,x", "5", t, env)
+	checkExprResultTest("`x", "x", t, env)
+	checkExprResultTest("(quasiquote (a (unquote x)))", "(a 5)", t, env)
+	checkExprResultTest("''a", "(quote a)", t, env)
+	// Unquotes in a nested quasiquote belong to it.
+	checkExprResultTest("`(a `(b ,(c ,x)))", "(a (quasiquote (b (unquote (c 5)))))", t, env)
+
+	malformedExprTest(",x", t, env)
+	malformedExprTest(",@xs", t, env)
+	malformedExprTest("`,@xs", t, env)
+	malformedExprTest("`(a ,@x)", t, env)
+	malformedExprTest("`(a ,undefinedVar)", t, env)
+	malformedExprTest("`", t, env)
+}
+
+func TestMacros(t *testing.T) {
+	env := new(LangEnv)
+	env.Init()
+
+	checkExprResultTest("(defmacro my-if (c then else) `(cond (,c ,then) (true ,else)))", "<Macro: my-if>", t, env)
+	checkExprResultTest("(my-if true 1 2)", "1", t, env)
+	// The operands are not evaluated before the macro gets them.
+	checkExprResultTest("(my-if false (/ 1 0) 2)", "2", t, env)
+	malformedExprTest("(my-if true 1)", t, env)
+
+	saneExprTest("(defmacro my-unless (c . body) `(cond (,c '()) (true (begin ,@body))))", t, env)
+	checkExprResultTest("(my-unless false 1 2 3)", "3", t, env)
+	checkExprResultTest("(my-unless true (/ 1 0))", "()", t, env)
+
+	// Macros get the forms as data.
+	saneExprTest("(defmacro first-symbol (form) `(quote ,(car form)))", t, env)
+	checkExprResultTest("(first-symbol (foo bar))", "foo", t, env)
+	saneExprTest("(defmacro count-args (. args) (length args))", t, env)
+	checkExprResultTest("(count-args a (b c) \"d\")", "3", t, env)
+
+	// gensym makes names which can not clash with the ones in the operands.
+	saneExprTest("(defmacro swap! (a b) (let ((tmp (gensym))) `(let ((,tmp ,a)) (set! ,a ,b) (set! ,b ,tmp))))", t, env)
+	saneExprTest("(defvar tmp 1)", t, env)
+	saneExprTest("(defvar other 2)", t, env)
+	saneExprTest("(swap! tmp other)", t, env)
+	checkExprResultTest("tmp", "2", t, env)
+	checkExprResultTest("other", "1", t, env)
+	checkExprResultTest("(= (gensym) (gensym))", "false", t, env)
+	checkExprResultTest("(car (list (gensym \"loop\")))", "loop{4}", t, env)
+	// The names can not be written in the source, so they can not clash with
+	// names written like them either.
+	saneExprTest("(defvar g__5 1)", t, env)
+	checkExprResultTest("(= (gensym) 'g__5)", "false", t, env)
+	malformedExprTest("(quote g{6})", t, env)
+
+	// Macros can expand into other macros, and into tail calls.
+	saneExprTest("(defmacro my-when (c . body) `(my-unless (not ,c) ,@body))", t, env)
+	checkExprResultTest("(my-when (> 2 1) 1 2)", "2", t, env)
+	saneExprTest("(defun count-down (n) (my-if (= n 0) \"done\" (count-down (- n 1))))", t, env)
+	checkExprResultTest("(count-down 150000)", "\"done\"", t, env)
+
+	checkExprResultTest("(macroexpand-1 '(my-when x 1))", "(my-unless (not x) 1)", t, env)
+	checkExprResultTest("(macroexpand '(my-when x 1))", "(cond ((not x) (quote ())) (true (begin 1)))", t, env)
+	checkExprResultTest("(macroexpand '(+ 1 2))", "(+ 1 2)", t, env)
+	checkExprResultTest("(macroexpand 5)", "5", t, env)
+	malformedExprTest("(macroexpand '(my-if 1))", t, env)
+
+	malformedExprTest("(defmacro my-if (x) x)", t, env)
+	malformedExprTest("(defmacro + (x) x)", t, env)
+	malformedExprTest("(defmacro 1 (x) x)", t, env)
+	malformedExprTest("(defmacro bad (1) 1)", t, env)
+	saneExprTest("(defmacro broken () (car 1))", t, env)
+	malformedExprTest("(broken)", t, env)
+}
+
+func TestSyntaxRules(t *testing.T) {
+	env := new(LangEnv)
+	env.Init()
+
+	checkExprResultTest("(define-syntax my-or (syntax-rules () ((_) false) ((_ e) e) ((_ e r ...) (let ((t e)) (if t t (my-or r ...))))))",
+		"<Macro: my-or>", t, env)
+	checkExprResultTest("(my-or)", "false", t, env)
+	checkExprResultTest("(my-or false 2)", "2", t, env)
+	checkExprResultTest("(my-or false false 3)", "3", t, env)
+	checkExprResultTest("(my-or 1 (/ 1 0))", "1", t, env)
+
+	// The t bound by the template does not capture the t of the caller.
+	saneExprTest("(defvar t 5)", t, env)
+	checkExprResultTest("(my-or false t)", "5", t, env)
+	checkExprResultTest("(let ((t 7)) (my-or false t))", "7", t, env)
+
+	// Bindings around the call do not change the names in the template.
+	checkExprResultTest("(let ((if list) (let 1)) (my-or false 4))", "4", t, env)
+	saneExprTest("(define-syntax swap! (syntax-rules () ((_ a b) (let ((tmp a)) (set! a b) (set! b tmp)))))", t, env)
+	saneExprTest("(defvar tmp 1)", t, env)
+	saneExprTest("(defvar other 2)", t, env)
+	saneExprTest("(swap! tmp other)", t, env)
+	checkExprResultTest("(list tmp other)", "(2 1)", t, env)
+	checkExprResultTest("(let ((set! 1) (x 1) (y 2)) (swap! x y) (list x y))", "(2 1)", t, env)
+
+	// Ellipses can follow lists, and be nested.
+	saneExprTest("(define-syntax my-let (syntax-rules () ((_ ((name val) ...) body1 body2 ...) ((lambda (name ...) body1 body2 ...) val ...))))", t, env)
+	checkExprResultTest("(my-let ((a 1) (b 2)) (+ a b))", "3", t, env)
+	checkExprResultTest("(my-let () 1 2)", "2", t, env)
+	saneExprTest("(define-syntax flatten (syntax-rules () ((_ (x ...) ...) '(x ... ...))))", t, env)
+	checkExprResultTest("(flatten (1 2) () (3))", "(1 2 3)", t, env)
+	saneExprTest("(define-syntax rev-pairs (syntax-rules () ((_ (a b) ...) '((b a) ...))))", t, env)
+	checkExprResultTest("(rev-pairs (1 2) (3 4))", "((2 1) (4 3))", t, env)
+	saneExprTest("(define-syntax last-of (syntax-rules () ((_ x ... y) 'y)))", t, env)
+	checkExprResultTest("(last-of 1 2 3)", "3", t, env)
+	saneExprTest("(define-syntax tail-of (syntax-rules () ((_ x . rest) 'rest)))", t, env)
+	checkExprResultTest("(tail-of 1 2 3)", "(2 3)", t, env)
+	saneExprTest("(define-syntax escaped (syntax-rules () ((_ x) '(x (... ...)))))", t, env)
+	checkExprResultTest("(escaped 1)", "(1 ...)", t, env)
+
+	// Literals have to match exactly.
+	saneExprTest("(define-syntax my-cond (syntax-rules (else) ((_ (else e)) e) ((_ (c e) clause ...) (if c e (my-cond clause ...)))))", t, env)
+	checkExprResultTest("(my-cond (false 1) (else 2))", "2", t, env)
+	checkExprResultTest("(my-cond ((= 1 1) 1) (else 2))", "1", t, env)
+	malformedExprTest("(my-cond (false 1))", t, env)
+	saneExprTest("(define-syntax is-arrow (syntax-rules (=>) ((_ =>) true) ((_ x) false)))", t, env)
+	checkExprResultTest("(is-arrow =>)", "true", t, env)
+	checkExprResultTest("(is-arrow 1)", "false", t, env)
+
+	// Keywords and quoted names in templates work as if they were not renamed.
+	saneExprTest("(define-syntax classify (syntax-rules () ((_ x) (cond ((> x 0) 'positive) (else 'other)))))", t, env)
+	checkExprResultTest("(classify 1)", "positive", t, env)
+	checkExprResultTest("(classify -1)", "other", t, env)
+	saneExprTest("(define-syntax make-list (syntax-rules () ((_ x ...) `(start ,x ... end))))", t, env)
+	checkExprResultTest("(make-list 1 2)", "(start 1 2 end)", t, env)
+
+	saneExprTest("(defun count-down (n) (my-or (= n 0) (count-down (- n 1))))", t, env)
+	checkExprResultTest("(count-down 1000)", "true", t, env)
+	// The names an expansion makes are only known while it is evaluated, and
+	// can not be written in the source.
+	if env.aliases != nil {
+		t.Errorf("Expected the global frame to have no aliases, got %d", len(env.aliases))
+	}
+	checkExprResultTest("(let ((t__1 8)) (my-or false t__1))", "8", t, env)
+	malformedExprTest("(quote t{1})", t, env)
+	// Definitions made by an expansion are made where the macro is used.
+	saneExprTest("(define-syntax def-double (syntax-rules () ((_ n v) (define n (* 2 v)))))", t, env)
+	saneExprTest("(def-double doubled 3)", t, env)
+	checkExprResultTest("doubled", "6", t, env)
+	// Expansions are shown with the names from the template.
+	checkExprResultTest("(macroexpand '(my-let ((a 1)) a))", "((lambda (a) a) 1)", t, env)
+
+	malformedExprTest("(my-let (a) a)", t, env)
+	malformedExprTest("(syntax-rules () ((_ x) x))", t, env)
+	// Macros can have any number of rules.
+	var rules bytes.Buffer
+	for i := 0; i < 150; i++ {
+		fmt.Fprintf(&rules, "((_ %d) 'rule-%d) ", i, i)
+	}
+	saneExprTest(fmt.Sprintf("(define-syntax many-rules (syntax-rules () %s))", rules.String()), t, env)
+	checkExprResultTest("(many-rules 149)", "rule-149", t, env)
+	result := Eval(fmt.Sprintf("(syntax-rules () %s)", rules.String()), env)
+	var syntaxErr *SyntaxError
+	if !errors.As(result.Err, &syntaxErr) {
+		t.Errorf("Expected a SyntaxError for syntax-rules outside define-syntax, got %T: %s", result.Err, result.ErrStr)
+	}
+	malformedExprTest("(define-syntax bad (lambda (x) x))", t, env)
+	malformedExprTest("(define-syntax bad (syntax-rules () ((_ ... x) x)))", t, env)
+	malformedExprTest("(define-syntax bad (syntax-rules () (_ x)))", t, env)
+	malformedExprTest("(define-syntax my-or (syntax-rules () ((_) 1)))", t, env)
+	saneExprTest("(define-syntax bad (syntax-rules () ((_ x ...) x)))", t, env)
+	malformedExprTest("(bad 1 2)", t, env)
+	saneExprTest("(define-syntax bad2 (syntax-rules () ((_ x) (x ...))))", t, env)
+	malformedExprTest("(bad2 1)", t, env)
+}
+
+func TestStrings(t *testing.T) {
+	env := new(LangEnv)
+	env.Init()
+
+	checkExprResultTest("\"hello world\"", "\"hello world\"", t, env)
+	checkExprResultTest("\"(x)\"", "\"(x)\"", t, env)
+	checkExprResultTest("\"  spaced  out  \"", "\"  spaced  out  \"", t, env)
+	checkExprResultTest("\"line\\nbreak\"", "\"line\\nbreak\"", t, env)
+	checkExprResultTest("\"say \\\"hi\\\"\"", "\"say \\\"hi\\\"\"", t, env)
+	checkExprResultTest("\"back\\\\slash\"", "\"back\\\\slash\"", t, env)
+	checkExprResultTest("\"caf\\u00e9\"", "\"café\"", t, env)
+	checkExprResultTest("\"café\"", "\"café\"", t, env)
+	checkExprResultTest("(+ \"hello\" \" \" \"world\")", "\"hello world\"", t, env)
+	checkExprResultTest("(list \"a b\" \"c)\")", "(\"a b\" \"c)\")", t, env)
+	checkExprResultTest("(= \"a b\" \"a b\")", "true", t, env)
+	malformedExprTest("\"unterminated", t, env)
+	malformedExprTest("\"bad \\q escape\"", t, env)
+
+	// Names can use the usual Lisp punctuation.
+	saneExprTest("(defvar *global* 1)", t, env)
+	checkExprResultTest("*global*", "1", t, env)
+	saneExprTest("(defun list->string? (x) (+ x 1))", t, env)
+	checkExprResultTest("(list->string? 1)", "2", t, env)
+	checkExprResultTest("(let ((a-b! 2)) a-b!)", "2", t, env)
+}
+
+func TestComments(t *testing.T) {
+	env := new(LangEnv)
+	env.Init()
+
+	checkExprResultTest("(+ 1 ; the first operand\n 2) ; the end", "3", t, env)
+	checkExprResultTest("; a comment on its own line\n(* 2 3)", "6", t, env)
+	checkExprResultTest("(list \"a ; b\" 1)", "(\"a ; b\" 1)", t, env)
+	checkExprResultTest("#| a block comment |# (+ 1 2)", "3", t, env)
+	checkExprResultTest("(+ 1 #| spanning\nlines |# 2)", "3", t, env)
+	checkExprResultTest("#| outer #| nested |# still outer |# 4", "4", t, env)
+	checkExprResultTest("(list 1 #;2 3)", "(1 3)", t, env)
+	checkExprResultTest("(list 1 #;(2 (3)) 4)", "(1 4)", t, env)
+	checkExprResultTest("(list 1 #;2)", "(1)", t, env)
+	checkExprResultTest("#;(undefined method) 5", "5", t, env)
+	checkExprResultTest("'(a #;b c)", "(a c)", t, env)
+
+	// Comments on their own are not an error, there is just nothing to print.
+	val := Eval("; nothing to see here", env)
+	if len(val.ValStr) != 0 || len(val.ErrStr) != 0 {
+		t.Errorf("Expected a comment to evaluate to nothing, got %s, %s", val.ValStr, val.ErrStr)
+	}
+
+	malformedExprTest("#| unterminated", t, env)
+	malformedExprTest("#| #| nested |# unterminated", t, env)
+	malformedExprTest("#;", t, env)
+	malformedExprTest("(list #;)", t, env)
+}
+
+func checkErrSpan(src *Source, env *LangEnv, line, col, endLine, endCol int, t *testing.T) {
+	val := src.Eval(env)
+	if len(val.ErrStr) == 0 {
+		t.Errorf("Expected an error in %s, got %s", src.Name, val.ValStr)
+		return
+	}
+	if val.ErrSpan == nil {
+		t.Errorf("Expected the error '%s' to have a span", val.ErrStr)
+		return
+	}
+	span := *val.ErrSpan
+	if span.File != src.Name || span.Line != line || span.Col != col ||
+		span.EndLine != endLine || span.EndCol != endCol {
+		t.Errorf("Expected the error '%s' at %s:%d:%d-%d:%d, was at %s-%d:%d",
+			val.ErrStr, src.Name, line, col, endLine, endCol, span, span.EndLine, span.EndCol)
+	}
+}
+
+func TestSourcePositions(t *testing.T) {
+	env := new(LangEnv)
+	env.Init()
+
+	src := NewSource("test.l", "(defvar x 1) ; comment\n(+ x\n   (foo 2))\n  undefinedVar\n(cond\n  ((> x 0) (car '())))\n(/ 1 0)")
+	if val := src.Eval(env); val.ValStr != "1" {
+		t.Errorf("Expected (defvar x 1) to be 1, was %s", val.ValStr)
+	}
+	checkErrSpan(src, env, 3, 4, 3, 11, t)
+	checkErrSpan(src, env, 4, 3, 4, 15, t)
+	checkErrSpan(src, env, 6, 12, 6, 21, t)
+	checkErrSpan(src, env, 7, 1, 7, 8, t)
+	if src.More() {
+		t.Errorf("Expected nothing to be left in the source")
+	}
+
+	// Errors from the reader have spans too.
+	checkErrSpan(NewSource("test.l", "(+ 1\n  2"), env, 1, 1, 1, 2, t)
+	src = NewSource("test.l", "(+ 1 2))")
+	src.Eval(env)
+	checkErrSpan(src, env, 1, 8, 1, 9, t)
+	checkErrSpan(NewSource("test.l", "1 \"abc"), env, 1, 3, 1, 7, t)
+	checkErrSpan(NewSource("test.l", "#| abc\n"), env, 1, 1, 2, 1, t)
+
+	// Errors in tail calls point into the body of the method.
+	src = NewSource("test.l", "(defun bad (x) (cond ((= x 0) (undefinedMethod 1)) (true (bad (- x 1)))))\n(bad 3)")
+	src.Eval(env)
+	checkErrSpan(src, env, 1, 31, 1, 50, t)
+
+	src = NewSource("test.l", "(list 1\n\t(car 2))")
+	val := src.Eval(env)
+	expected := "\t(car 2))\n\t^~~~~~~"
+	if val.ErrSpan == nil || src.Excerpt(*val.ErrSpan) != expected {
+		t.Errorf("Expected the excerpt to be %q, was %q", expected, src.Excerpt(*val.ErrSpan))
+	}
+}
+
 func checkOfType(value string, expectedType Value, t *testing.T) {
 	if !expectedType.ofType(value) {
 		t.Errorf("Expected %s to be of type %s, but was not.", value,
@@ -206,8 +1647,14 @@ func checkNotOfType(value string, unexpectedType Value, t *testing.T) {
 
 // Adding some simple type checks.
 func TestTypes(t *testing.T) {
-	checkOfType("'hello'", new(stringValue), t)
 	checkOfType("\"hello\"", new(stringValue), t)
+	checkOfType("\"hello world\"", new(stringValue), t)
+	checkOfType("\"(x)\"", new(stringValue), t)
+	checkOfType("\"say \\\"hi\\\"\\n\"", new(stringValue), t)
+	checkNotOfType("'hello'", new(stringValue), t)
+	checkNotOfType("\"hello", new(stringValue), t)
+	checkNotOfType("\"a\"b\"", new(stringValue), t)
+	checkNotOfType("\"\\q\"", new(stringValue), t)
 	checkNotOfType("123", new(stringValue), t)
 	checkNotOfType("1.23", new(stringValue), t)
 	checkNotOfType("true", new(stringValue), t)
@@ -236,6 +1683,22 @@ func TestTypes(t *testing.T) {
 	checkNotOfType("deadbeef", new(bigIntValue), t)
 	checkNotOfType("true", new(bigIntValue), t)
 	checkNotOfType("false", new(bigIntValue), t)
+
+	checkOfType("foo", new(varValue), t)
+	checkOfType("list->vector", new(varValue), t)
+	checkOfType("null?", new(varValue), t)
+	checkOfType("set!", new(varValue), t)
+	checkOfType("*global*", new(varValue), t)
+	checkOfType("+", new(varValue), t)
+	checkOfType("-", new(varValue), t)
+	checkOfType("...", new(varValue), t)
+	checkOfType("ünïcödé", new(varValue), t)
+	checkNotOfType("-1", new(varValue), t)
+	checkNotOfType("1abc", new(varValue), t)
+	checkNotOfType(".5", new(varValue), t)
+	checkNotOfType(".", new(varValue), t)
+	checkNotOfType("a[b]", new(varValue), t)
+	checkNotOfType("\"foo\"", new(varValue), t)
 }
 
 func checkTypeInitUsingStrMatches(vType Value, targetStr string, t *testing.T) {
@@ -260,3 +1723,83 @@ func TestValueInitializations(t *testing.T) {
 	checkTypeInitUsingStrMatches(iv, "12345678912345", t)
 	checkTypeInitUsingStrMatches(iv, "-12345678912345", t)
 }
+
+func TestErrorTypes(t *testing.T) {
+	env := new(LangEnv)
+	env.Init()
+
+	val := Eval("(/ 1 0)", env)
+	var divErr *DivideByZeroError
+	if !errors.As(val.Err, &divErr) {
+		t.Fatalf("Expected a DivideByZeroError, got %T: %s", val.Err, val.ErrStr)
+	}
+	if divErr.Operator != div || len(divErr.Operands) != 2 || divErr.Operands[0].Str() != "1" {
+		t.Errorf("Unexpected operator %s, or operands %v", divErr.Operator, divErr.Operands)
+	}
+	if divErr.Pos.Line != 1 || divErr.Pos.Col != 1 || divErr.Pos.EndCol != 8 {
+		t.Errorf("Unexpected position %s", divErr.Pos)
+	}
+
+	val = Eval("(car 1 2)", env)
+	var arityErr *ArityError
+	if !errors.As(val.Err, &arityErr) {
+		t.Fatalf("Expected an ArityError, got %T: %s", val.Err, val.ErrStr)
+	}
+	if arityErr.Operator != car || arityErr.Received != 2 || arityErr.Min != 1 || arityErr.Max != 1 {
+		t.Errorf("Unexpected arity error %+v", arityErr)
+	}
+
+	val = Eval("(+ 1 (car \"abc\"))", env)
+	var typeErr *TypeError
+	if !errors.As(val.Err, &typeErr) {
+		t.Fatalf("Expected a TypeError, got %T: %s", val.Err, val.ErrStr)
+	}
+	if typeErr.Operator != car || len(typeErr.Operands) != 1 || typeErr.Operands[0].Str() != "\"abc\"" {
+		t.Errorf("Unexpected operator %s, or operands %v", typeErr.Operator, typeErr.Operands)
+	}
+	if typeErr.Pos.Col != 6 || typeErr.Pos.EndCol != 17 {
+		t.Errorf("Unexpected position %s", typeErr.Pos)
+	}
+
+	val = Eval("(+ 1 undefinedVar)", env)
+	var undefErr *UndefinedError
+	if !errors.As(val.Err, &undefErr) || undefErr.Name != "undefinedVar" || undefErr.IsOperator {
+		t.Errorf("Expected an UndefinedError for undefinedVar, got %T: %s", val.Err, val.ErrStr)
+	}
+	val = Eval("(undefinedMethod 1)", env)
+	if !errors.As(val.Err, &undefErr) || undefErr.Name != "undefinedMethod" || !undefErr.IsOperator {
+		t.Errorf("Expected an UndefinedError for undefinedMethod, got %T: %s", val.Err, val.ErrStr)
+	}
+	val = Eval("(undefinedMethod)", env)
+	if !errors.As(val.Err, &undefErr) || undefErr.Name != "undefinedMethod" || !undefErr.IsOperator {
+		t.Errorf("Expected an UndefinedError for undefinedMethod, got %T: %s", val.Err, val.ErrStr)
+	}
+
+	for _, exp := range []string{"(1 2)", "(\"f\" 1)", "((+ 1 2) 3)"} {
+		val = Eval(exp, env)
+		if !errors.As(val.Err, &typeErr) {
+			t.Errorf("Expected a TypeError for %s, got %T: %s", exp, val.Err, val.ErrStr)
+		}
+	}
+
+	var syntaxErr *SyntaxError
+	for _, exp := range []string{"(+ 1 2", "(let (x 1) x)", ""} {
+		val = Eval(exp, env)
+		if !errors.As(val.Err, &syntaxErr) {
+			t.Errorf("Expected a SyntaxError for %s, got %T: %s", exp, val.Err, val.ErrStr)
+		}
+	}
+
+	saneExprTest("(defun deep (n) (cond ((= n 0) 0) (true (+ 1 (deep (- n 1))))))", t, env)
+	val = Eval("(deep 200000)", env)
+	var recursionErr *RecursionLimitError
+	if !errors.As(val.Err, &recursionErr) || recursionErr.Limit != maxRecursionLimit {
+		t.Errorf("Expected a RecursionLimitError, got %T: %s", val.Err, val.ErrStr)
+	}
+
+	val = Eval("(defvar + 1)", env)
+	var evalErr *EvalError
+	if !errors.As(val.Err, &evalErr) || evalErr.Operator != def {
+		t.Errorf("Expected an EvalError from %s, got %T: %s", def, val.Err, val.ErrStr)
+	}
+}
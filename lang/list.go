@@ -0,0 +1,210 @@
+package lang
+
+import (
+	"math"
+)
+
+const (
+	// List operators
+	cons       string = "cons"
+	car        string = "car"
+	cdr        string = "cdr"
+	list       string = "list"
+	isNull     string = "null?"
+	isPair     string = "pair?"
+	length     string = "length"
+	appendList string = "append"
+	reverse    string = "reverse"
+)
+
+// Builds a proper list out of the given values.
+func newList(values []Value) Value {
+	var result Value = emptyListValue{}
+	for i := len(values) - 1; i >= 0; i-- {
+		result = newPairValue(values[i], result)
+	}
+	return result
+}
+
+// Returns the elements of a proper list, or an error if the value is not one.
+func listToSlice(listVal Value) ([]Value, error) {
+	values := make([]Value, 0)
+	rest := listVal
+	for {
+		switch v := rest.(type) {
+		case emptyListValue:
+			return values, nil
+		case pairValue:
+			values = append(values, v.car)
+			rest = v.cdr
+		default:
+			return nil, newTypeError("", []Value{listVal}, "%s is not a proper list.", listVal.Str())
+		}
+	}
+}
+
+func addListOperators(opMap map[string]*Operator) {
+	pairTypes := []valueType{pairType}
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      cons,
+			minArgCount: 2,
+			maxArgCount: 2,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				retVal.Val = newPairValue(operands[0].Val, operands[1].Val)
+				return retVal
+			},
+		},
+	)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      car,
+			minArgCount: 1,
+			maxArgCount: 1,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				_, retVal.Err = checkArgTypes(car, &operands, pairTypes)
+				if retVal.Err != nil {
+					return retVal
+				}
+				retVal.Val = operands[0].Val.(pairValue).car
+				return retVal
+			},
+		},
+	)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      cdr,
+			minArgCount: 1,
+			maxArgCount: 1,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				_, retVal.Err = checkArgTypes(cdr, &operands, pairTypes)
+				if retVal.Err != nil {
+					return retVal
+				}
+				retVal.Val = operands[0].Val.(pairValue).cdr
+				return retVal
+			},
+		},
+	)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      list,
+			minArgCount: 0,
+			maxArgCount: math.MaxInt32,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				values := make([]Value, 0, len(operands))
+				for _, o := range operands {
+					values = append(values, o.Val)
+				}
+				retVal.Val = newList(values)
+				return retVal
+			},
+		},
+	)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      isNull,
+			minArgCount: 1,
+			maxArgCount: 1,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				retVal.Val = newBoolValue(operands[0].Val.getValueType() == emptyType)
+				return retVal
+			},
+		},
+	)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      isPair,
+			minArgCount: 1,
+			maxArgCount: 1,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				retVal.Val = newBoolValue(operands[0].Val.getValueType() == pairType)
+				return retVal
+			},
+		},
+	)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      length,
+			minArgCount: 1,
+			maxArgCount: 1,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				values, err := listToSlice(operands[0].Val)
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				var val intValue
+				val.value = int64(len(values))
+				retVal.Val = val
+				return retVal
+			},
+		},
+	)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      appendList,
+			minArgCount: 0,
+			maxArgCount: math.MaxInt32,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				if len(operands) == 0 {
+					retVal.Val = emptyListValue{}
+					return retVal
+				}
+
+				// The last list is shared with the result, all the others are copied.
+				result := operands[len(operands)-1].Val
+				for i := len(operands) - 2; i >= 0; i-- {
+					values, err := listToSlice(operands[i].Val)
+					if err != nil {
+						retVal.Err = err
+						return retVal
+					}
+					for j := len(values) - 1; j >= 0; j-- {
+						result = newPairValue(values[j], result)
+					}
+				}
+				retVal.Val = result
+				return retVal
+			},
+		},
+	)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      reverse,
+			minArgCount: 1,
+			maxArgCount: 1,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				values, err := listToSlice(operands[0].Val)
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				var result Value = emptyListValue{}
+				for _, v := range values {
+					result = newPairValue(v, result)
+				}
+				retVal.Val = result
+				return retVal
+			},
+		},
+	)
+}
@@ -0,0 +1,318 @@
+package lang
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// Macro operators
+	defmacro        string = "defmacro"
+	quasiquote      string = "quasiquote"
+	unquote         string = "unquote"
+	unquoteSplicing string = "unquote-splicing"
+	gensym          string = "gensym"
+	macroexpand     string = "macroexpand"
+	macroexpand1    string = "macroexpand-1"
+)
+
+// Returns true if the node is a list of two elements, whose first element is
+// the given keyword, like (unquote x).
+func isForm(env *LangEnv, node *ASTNode, keyword string) bool {
+	return !node.isValue && len(node.children) == 2 && env.isKeyword(node.children[0], keyword)
+}
+
+// This method builds the value of a quasiquoted template. Parts of it which
+// are unquoted are evaluated, and everything else is quoted. depth is the
+// number of quasiquotes the node is in, so that the unquotes which belong to
+// a nested quasiquote are left alone.
+func quasiquoteValue(env *LangEnv, node *ASTNode, depth int) (Value, error) {
+	if node.isValue {
+		value, err := astToValue(env, node)
+		if err != nil {
+			return nil, err
+		}
+		return syntaxToDatum(env, value), nil
+	}
+
+	for _, name := range []string{unquote, unquoteSplicing, quasiquote} {
+		if !isForm(env, node, name) {
+			continue
+		}
+		innerDepth := depth + 1
+		if name != quasiquote {
+			innerDepth = depth - 1
+		}
+		if innerDepth == 0 {
+			if name == unquoteSplicing {
+				return nil, withSpan(newSyntaxError("%s can only be used inside a list.", unquoteSplicing), node.span)
+			}
+			v := evalASTHelper(env, node.children[1])
+			return v.Val, v.Err
+		}
+		inner, err := quasiquoteValue(env, node.children[1], innerDepth)
+		if err != nil {
+			return nil, err
+		}
+		return newList([]Value{newSymbolValue(name), inner}), nil
+	}
+
+	children := node.children
+	// (a b . c) is a list whose last cdr is c, instead of the empty list.
+	var tail Value = emptyListValue{}
+	if n := len(children); n >= 3 && children[n-2].isValue && children[n-2].value == dot {
+		var err error
+		tail, err = quasiquoteValue(env, children[n-1], depth)
+		if err != nil {
+			return nil, err
+		}
+		children = children[:n-2]
+	}
+
+	values := make([]Value, 0, len(children))
+	for _, child := range children {
+		// ,@x splices the elements of the list x into the list around it.
+		if depth == 1 && isForm(env, child, unquoteSplicing) {
+			v := evalASTHelper(env, child.children[1])
+			if v.Err != nil {
+				return nil, v.Err
+			}
+			spliced, err := listToSlice(v.Val)
+			if err != nil {
+				return nil, withSpan(withOperator(err, unquoteSplicing), child.span)
+			}
+			values = append(values, spliced...)
+			continue
+		}
+		value, err := quasiquoteValue(env, child, depth)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	for i := len(values) - 1; i >= 0; i-- {
+		tail = newPairValue(values[i], tail)
+	}
+	return tail, nil
+}
+
+// This method expands the form once, if it is a call to a macro. The second
+// return value is false if the form is not a call to a macro, in which case
+// it is returned as it is.
+func expandMacro(env *LangEnv, form Value) (Value, *LangEnv, bool, error) {
+	pair, ok := form.(pairValue)
+	if !ok {
+		return form, env, false, nil
+	}
+	name, ok := pair.car.(symbolValue)
+	if !ok {
+		return form, env, false, nil
+	}
+	operator := env.getOperator(name.value)
+	if operator == nil || operator.expander == nil {
+		return form, env, false, nil
+	}
+	expanded, expansionEnv, err := operator.expander(env, form)
+	return expanded, expansionEnv, true, err
+}
+
+// Returns the operator for a macro. A call to the macro is expanded by the
+// expander, and the expansion is evaluated in the place of the call.
+func newMacroOperator(name string, minArgCount, maxArgCount int,
+	expander func(*LangEnv, Value) (Value, *LangEnv, error)) *Operator {
+	op := new(Operator)
+	op.symbol = name
+	op.minArgCount = minArgCount
+	op.maxArgCount = maxArgCount
+	op.passRawAST = true
+	op.expander = expander
+	op.handler = func(env *LangEnv, operands []Atom) Atom {
+		var retVal Atom
+		node := operands[0].Val.(astValue).parentASTNode
+		form, err := astToValue(env, node)
+		if err != nil {
+			retVal.Err = err
+			return retVal
+		}
+		expanded, expansionEnv, err := expander(env, form)
+		if err != nil {
+			retVal.Err = err
+			return retVal
+		}
+		return newTailCall(expansionEnv, valueToAST(expanded, node.span))
+	}
+	return op
+}
+
+// Returns the operator for a macro defined with defmacro, whose expansion is
+// the result of calling the given lambda with the unevaluated operands.
+func newMacro(name string, macro lambdaValue) *Operator {
+	minArgCount := len(macro.params)
+	maxArgCount := len(macro.params)
+	if len(macro.rest) > 0 {
+		maxArgCount = math.MaxInt32
+	}
+
+	return newMacroOperator(name, minArgCount, maxArgCount, func(env *LangEnv, form Value) (Value, *LangEnv, error) {
+		values, err := listToSlice(form)
+		if err != nil {
+			return nil, env, err
+		}
+		operands := make([]Atom, 0, len(values)-1)
+		for _, v := range values[1:] {
+			var o Atom
+			o.Val = v
+			operands = append(operands, o)
+		}
+		if len(operands) < minArgCount || len(operands) > maxArgCount {
+			return nil, env, newArityError(name, len(operands), minArgCount, maxArgCount)
+		}
+
+		result := callLambda(env, macro, operands)
+		if tailCall, ok := result.Val.(tailCallValue); ok {
+			result = evalASTHelper(tailCall.env, tailCall.node)
+		}
+		return result.Val, env, withOperator(result.Err, name)
+	})
+}
+
+func addMacroOperators(opMap map[string]*Operator) {
+	addOperator(opMap,
+		&Operator{
+			symbol:      defmacro,
+			minArgCount: 3,
+			maxArgCount: math.MaxInt32,
+			passRawAST:  true,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				astVal, _ := operands[0].Val.(astValue)
+				macroName, err := getVarName(env, astVal.astNodes[0], defmacro)
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				if env.getValue(macroName) != nil {
+					retVal.Err = newEvalError("Macro %s already defined as a variable", macroName)
+					return retVal
+				}
+				if env.getOperator(macroName) != nil {
+					retVal.Err = newEvalError("Macro %s already defined as an operator", macroName)
+					return retVal
+				}
+
+				params, rest, err := getParams(env, astVal.astNodes[1], macroName)
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				macro := newLambdaValue(env, params, rest, astVal.astNodes[2:])
+				addOperator(env.definitionFrame().opMap, newMacro(macroName, macro))
+
+				var val varValue
+				val.value = fmt.Sprintf("<Macro: %s>", macroName)
+				val.varName = macroName
+				retVal.Val = val
+				return retVal
+			},
+		},
+	)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      quasiquote,
+			minArgCount: 1,
+			maxArgCount: 1,
+			passRawAST:  true,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				astVal, _ := operands[0].Val.(astValue)
+				retVal.Val, retVal.Err = quasiquoteValue(env, astVal.astNodes[0], 1)
+				return retVal
+			},
+		},
+	)
+
+	// unquote and unquote-splicing are handled by quasiquote, and are errors
+	// anywhere else.
+	for _, symbol := range []string{unquote, unquoteSplicing} {
+		unquoteSymbol := symbol
+		addOperator(opMap,
+			&Operator{
+				symbol:      unquoteSymbol,
+				minArgCount: 1,
+				maxArgCount: 1,
+				passRawAST:  true,
+				handler: func(env *LangEnv, operands []Atom) Atom {
+					var retVal Atom
+					retVal.Err = newSyntaxError("%s can only be used inside %s.", unquoteSymbol, quasiquote)
+					return retVal
+				},
+			},
+		)
+	}
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      gensym,
+			minArgCount: 0,
+			maxArgCount: 1,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				prefix := "g"
+				if len(operands) == 1 {
+					_, retVal.Err = checkArgTypes(gensym, &operands, []valueType{stringType, symbolType})
+					if retVal.Err != nil {
+						return retVal
+					}
+					prefix = operands[0].Val.Str()
+					if str, ok := operands[0].Val.(stringValue); ok {
+						prefix = str.value
+					}
+				}
+				retVal.Val = newSymbolValue(freshName(env, prefix))
+				return retVal
+			},
+		},
+	)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      macroexpand1,
+			minArgCount: 1,
+			maxArgCount: 1,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				var expansionEnv *LangEnv
+				retVal.Val, expansionEnv, _, retVal.Err = expandMacro(env, operands[0].Val)
+				if retVal.Err == nil {
+					retVal.Val = syntaxToDatum(expansionEnv, retVal.Val)
+				}
+				return retVal
+			},
+		},
+	)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      macroexpand,
+			minArgCount: 1,
+			maxArgCount: 1,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				form, expansionEnv := operands[0].Val, env
+				for {
+					expanded, nextEnv, ok, err := expandMacro(expansionEnv, form)
+					if err != nil {
+						retVal.Err = err
+						return retVal
+					}
+					if !ok {
+						retVal.Val = syntaxToDatum(expansionEnv, expanded)
+						return retVal
+					}
+					form, expansionEnv = expanded, nextEnv
+				}
+			},
+		},
+	)
+}
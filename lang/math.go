@@ -0,0 +1,568 @@
+package lang
+
+import (
+	"math"
+	"math/big"
+	"math/cmplx"
+)
+
+const (
+	// Math operators
+	quotient         string = "quotient"
+	remainder        string = "remainder"
+	mod              string = "mod"
+	abs              string = "abs"
+	minSymbol        string = "min"
+	maxSymbol        string = "max"
+	expt             string = "expt"
+	exactIntegerSqrt string = "exact-integer-sqrt"
+	floor            string = "floor"
+	ceiling          string = "ceiling"
+	round            string = "round"
+	truncate         string = "truncate"
+	exp              string = "exp"
+	log              string = "log"
+	sin              string = "sin"
+	cos              string = "cos"
+	tan              string = "tan"
+	asin             string = "asin"
+	acos             string = "acos"
+	atan             string = "atan"
+	gcd              string = "gcd"
+	lcm              string = "lcm"
+)
+
+func isWhole(f float64) bool {
+	return isFinite(f) && f == math.Trunc(f)
+}
+
+// This method divides two integers of the same type, for quotient, remainder
+// and mod. quotient rounds towards zero, the remainder has the sign of the
+// dividend, and mod has the sign of the divisor. Floats have to be whole
+// numbers, like 7.0.
+func divideIntegers(symbol string, a, b Value) (Value, error) {
+	switch x := a.(type) {
+	case intValue:
+		y := b.(intValue)
+		if y.value == 0 {
+			return nil, newDivideByZeroError(symbol, []Value{a, b})
+		}
+		if x.value == math.MinInt64 && y.value == -1 {
+			// The quotient does not fit in an int.
+			bigX, _ := x.to(bigIntType)
+			bigY, _ := y.to(bigIntType)
+			return divideIntegers(symbol, bigX, bigY)
+		}
+		var val intValue
+		switch symbol {
+		case quotient:
+			val.value = x.value / y.value
+		case remainder:
+			val.value = x.value % y.value
+		default:
+			val.value = x.value % y.value
+			if val.value != 0 && (val.value < 0) != (y.value < 0) {
+				val.value += y.value
+			}
+		}
+		return val, nil
+
+	case bigIntValue:
+		y := b.(bigIntValue)
+		if y.value.Sign() == 0 {
+			return nil, newDivideByZeroError(symbol, []Value{a, b})
+		}
+		q, r := new(big.Int).QuoRem(x.value, y.value, new(big.Int))
+		switch symbol {
+		case quotient:
+			return newBigIntValue(q), nil
+		case mod:
+			if r.Sign() != 0 && r.Sign() != y.value.Sign() {
+				r.Add(r, y.value)
+			}
+		}
+		return newBigIntValue(r), nil
+
+	case floatValue:
+		y := b.(floatValue)
+		for _, f := range []floatValue{x, y} {
+			if !isWhole(f.value) {
+				return nil, newTypeError(symbol, []Value{f}, "%s expects whole numbers, got %s.", symbol, f.Str())
+			}
+		}
+		if y.value == 0 {
+			return nil, newDivideByZeroError(symbol, []Value{a, b})
+		}
+		var val floatValue
+		switch symbol {
+		case quotient:
+			val.value = math.Trunc(x.value / y.value)
+		case remainder:
+			val.value = math.Mod(x.value, y.value)
+		default:
+			val.value = math.Mod(x.value, y.value)
+			if val.value != 0 && (val.value < 0) != (y.value < 0) {
+				val.value += y.value
+			}
+		}
+		return val, nil
+
+	case ratValue, decimalValue:
+		// Exact numbers which are whole are divided as integers. A decimal
+		// stays a decimal, but a rational is never whole.
+		var nums []Value
+		for _, v := range []Value{a, b} {
+			r, _ := v.to(ratType)
+			if !r.(ratValue).value.IsInt() {
+				return nil, newTypeError(symbol, []Value{v}, "%s expects whole numbers, got %s.", symbol, v.Str())
+			}
+			var num bigIntValue
+			num.value = r.(ratValue).value.Num()
+			nums = append(nums, num)
+		}
+		val, err := divideIntegers(symbol, nums[0], nums[1])
+		if err != nil || a.getValueType() != decimalType {
+			return val, err
+		}
+		bigVal, _ := val.to(bigIntType)
+		return newDecimalValue(bigVal.(bigIntValue).value, 0), nil
+	}
+	return nil, newTypeError(symbol, []Value{a}, "%s expects integers, got %s.", symbol, a.Str())
+}
+
+// Returns the number rounded to a whole number, of the same type, using the
+// given rounding mode.
+func roundNumber(v Value, mode string) Value {
+	switch val := v.(type) {
+	case ratValue:
+		return newBigIntValue(roundQuotient(val.value.Num(), val.value.Denom(), mode))
+	case decimalValue:
+		return decimalContext{rounding: mode}.rescale(val.rat(), 0)
+	case floatValue:
+		switch mode {
+		case roundFloor:
+			val.value = math.Floor(val.value)
+		case roundCeiling:
+			val.value = math.Ceil(val.value)
+		case roundDown:
+			val.value = math.Trunc(val.value)
+		default:
+			val.value = math.RoundToEven(val.value)
+		}
+		return val
+	}
+	return v
+}
+
+// Returns r^n, exactly.
+func exactPower(r *big.Rat, n int64) (*big.Rat, error) {
+	if r.Sign() == 0 && n < 0 {
+		return nil, newDivideByZeroError(expt, nil)
+	}
+	power := new(big.Int).Abs(big.NewInt(n))
+	num := new(big.Int).Exp(r.Num(), power, nil)
+	den := new(big.Int).Exp(r.Denom(), power, nil)
+	if n < 0 {
+		num, den = den, num
+	}
+	return new(big.Rat).SetFrac(num, den), nil
+}
+
+// The most bits the numerator or denominator of an exact power can have.
+// Larger powers would take too long to compute, and too much memory to hold.
+const maxExactPowerBits = 1 << 20
+
+// Returns an error if any of the parts of base, like its numerator and
+// denominator, would have more than maxExactPowerBits bits when raised to the
+// power n, or to the power -n.
+func checkPowerSize(base Value, n int64, parts ...*big.Int) error {
+	for _, x := range parts {
+		bits := int64(x.BitLen() - 1)
+		if bits > 0 && (n > maxExactPowerBits/bits || n < -maxExactPowerBits/bits) {
+			return newEvalError("%s to the power %d is too large to compute exactly.", base.Str(), n)
+		}
+	}
+	return nil
+}
+
+// This method raises base to the given power. Exact numbers raised to an
+// integer power give exact results, and decimals are rounded to the precision
+// of the context. Anything else is computed with floats, or complex numbers,
+// if the result is not real.
+func exptValue(c decimalContext, base, power Value) (Value, error) {
+	if n, ok := power.(intValue); ok {
+		switch b := base.(type) {
+		case intValue, bigIntValue, ratValue:
+			r, _ := toRat(base)
+			if err := checkPowerSize(base, n.value, r.Num(), r.Denom()); err != nil {
+				return nil, err
+			}
+			p, err := exactPower(r, n.value)
+			if err != nil {
+				return nil, err
+			}
+			return newRatValue(p), nil
+		case decimalValue:
+			// The scale grows with the power too, and so does the number of
+			// digits the result prints with.
+			r := b.rat()
+			if err := checkPowerSize(base, n.value, b.unscaled, r.Num(), r.Denom()); err != nil {
+				return nil, err
+			}
+			if n.value >= 0 {
+				unscaled := new(big.Int).Exp(b.unscaled, big.NewInt(n.value), nil)
+				return c.round(newDecimalValue(unscaled, b.scale*int(n.value))), nil
+			}
+			p, err := exactPower(r, n.value)
+			if err != nil {
+				return nil, err
+			}
+			return c.fromRat(p, 0), nil
+		case complexValue:
+			// Squaring repeatedly keeps results like (expt 1i 2) exact.
+			result := complex128(1)
+			square := b.value
+			for k := n.value; k != 0; k /= 2 {
+				if k%2 != 0 {
+					result *= square
+				}
+				square *= square
+			}
+			if n.value < 0 {
+				result = 1 / result
+			}
+			return newComplexValue(result), nil
+		}
+	}
+
+	_, baseIsComplex := base.(complexValue)
+	_, powerIsComplex := power.(complexValue)
+	x, _ := base.to(floatType)
+	y, _ := power.to(floatType)
+	if baseIsComplex || powerIsComplex ||
+		(x.(floatValue).value < 0 && !isWhole(y.(floatValue).value)) {
+		cx, _ := toComplex(base)
+		cy, _ := toComplex(power)
+		return newComplexValue(cmplx.Pow(cx, cy)), nil
+	}
+	return newFloatValue(math.Pow(x.(floatValue).value, y.(floatValue).value)), nil
+}
+
+// A floatFunc is a function like sin, which gives a float for the real
+// numbers in its domain, and a complex number for anything else.
+type floatFunc struct {
+	symbol      string
+	realFunc    func(float64) float64
+	complexFunc func(complex128) complex128
+	// nil if the domain is all the real numbers.
+	inDomain func(float64) bool
+}
+
+func (f floatFunc) apply(v Value) (Value, error) {
+	if c, ok := v.(complexValue); ok {
+		return newComplexValue(f.complexFunc(c.value)), nil
+	}
+	fv, err := v.to(floatType)
+	if err != nil {
+		return nil, err
+	}
+	x := fv.(floatValue).value
+	if f.inDomain != nil && !f.inDomain(x) {
+		return newComplexValue(f.complexFunc(complex(x, 0))), nil
+	}
+	return newFloatValue(f.realFunc(x)), nil
+}
+
+func addMathOperators(opMap map[string]*Operator) {
+	realTypes := []valueType{intType, bigIntType, decimalType, ratType, floatType}
+	numTypes := append(realTypes, complexType)
+	// The same order as for arithmetic, but for complex numbers, which are
+	// never integers.
+	integerPrecedenceMap := map[valueType]int{intType: 1, bigIntType: 2, decimalType: 3, ratType: 4, floatType: 5}
+
+	for _, symbol := range []string{quotient, remainder, mod} {
+		divSymbol := symbol
+		addOperator(opMap,
+			&Operator{
+				symbol:      divSymbol,
+				minArgCount: 2,
+				maxArgCount: 2,
+				handler: func(env *LangEnv, operands []Atom) Atom {
+					var retVal Atom
+					_, retVal.Err = typeCoerce(divSymbol, &operands, integerPrecedenceMap)
+					if retVal.Err != nil {
+						return retVal
+					}
+					retVal.Val, retVal.Err = divideIntegers(divSymbol, operands[0].Val, operands[1].Val)
+					return retVal
+				},
+			},
+		)
+	}
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      abs,
+			minArgCount: 1,
+			maxArgCount: 1,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				_, retVal.Err = checkArgTypes(abs, &operands, realTypes)
+				if retVal.Err != nil {
+					return retVal
+				}
+				retVal.Val = absValue(operands[0].Val)
+				return retVal
+			},
+		},
+	)
+
+	// min and max give a float if any of their operands is a float, as the
+	// result is then only as exact as that float.
+	for _, symbol := range []string{minSymbol, maxSymbol} {
+		extremeSymbol := symbol
+		addOperator(opMap,
+			&Operator{
+				symbol:      extremeSymbol,
+				minArgCount: 1,
+				maxArgCount: math.MaxInt32,
+				handler: func(env *LangEnv, operands []Atom) Atom {
+					var retVal Atom
+					var typesFound map[valueType]int
+					typesFound, retVal.Err = checkArgTypes(extremeSymbol, &operands, realTypes)
+					if retVal.Err != nil {
+						return retVal
+					}
+					result := operands[0].Val
+					for _, o := range operands[1:] {
+						c, ordered := compareReals(o.Val, result)
+						if !ordered {
+							retVal.Val = newFloatValue(math.NaN())
+							return retVal
+						}
+						if (extremeSymbol == minSymbol && c < 0) || (extremeSymbol == maxSymbol && c > 0) {
+							result = o.Val
+						}
+					}
+					if typesFound[floatType] > 0 {
+						result, retVal.Err = result.to(floatType)
+					}
+					retVal.Val = result
+					return retVal
+				},
+			},
+		)
+	}
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      expt,
+			minArgCount: 2,
+			maxArgCount: 2,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				_, retVal.Err = checkArgTypes(expt, &operands, numTypes)
+				if retVal.Err != nil {
+					return retVal
+				}
+				retVal.Val, retVal.Err = exptValue(env.global().decimals, operands[0].Val, operands[1].Val)
+				return retVal
+			},
+		},
+	)
+
+	// (exact-integer-sqrt n) is the list (s r), where s is the largest integer
+	// whose square is at most n, and r is n - s^2.
+	addOperator(opMap,
+		&Operator{
+			symbol:      exactIntegerSqrt,
+			minArgCount: 1,
+			maxArgCount: 1,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				_, retVal.Err = checkArgTypes(exactIntegerSqrt, &operands, []valueType{intType, bigIntType})
+				if retVal.Err != nil {
+					return retVal
+				}
+				n, _ := operands[0].Val.to(bigIntType)
+				if n.(bigIntValue).value.Sign() < 0 {
+					retVal.Err = newTypeError(exactIntegerSqrt, []Value{operands[0].Val},
+						"%s expects a non-negative integer, got %s.", exactIntegerSqrt, operands[0].Val.Str())
+					return retVal
+				}
+				root, _ := isqrt(n.(bigIntValue).value)
+				rest := new(big.Int).Sub(n.(bigIntValue).value, new(big.Int).Mul(root, root))
+				retVal.Val = newList([]Value{newBigIntValue(root), newBigIntValue(rest)})
+				return retVal
+			},
+		},
+	)
+
+	// Integers are already whole, and are left as they are. Like in Scheme,
+	// round rounds halves to the even number.
+	roundingOperators := map[string]string{
+		floor:    roundFloor,
+		ceiling:  roundCeiling,
+		round:    roundHalfEven,
+		truncate: roundDown,
+	}
+	for _, symbol := range []string{floor, ceiling, round, truncate} {
+		roundSymbol := symbol
+		mode := roundingOperators[roundSymbol]
+		addOperator(opMap,
+			&Operator{
+				symbol:      roundSymbol,
+				minArgCount: 1,
+				maxArgCount: 1,
+				handler: func(env *LangEnv, operands []Atom) Atom {
+					var retVal Atom
+					_, retVal.Err = checkArgTypes(roundSymbol, &operands, realTypes)
+					if retVal.Err != nil {
+						return retVal
+					}
+					retVal.Val = roundNumber(operands[0].Val, mode)
+					return retVal
+				},
+			},
+		)
+	}
+
+	inUnitInterval := func(x float64) bool { return x >= -1 && x <= 1 }
+	floatFuncs := []floatFunc{
+		{exp, math.Exp, cmplx.Exp, nil},
+		{sin, math.Sin, cmplx.Sin, nil},
+		{cos, math.Cos, cmplx.Cos, nil},
+		{tan, math.Tan, cmplx.Tan, nil},
+		{asin, math.Asin, cmplx.Asin, inUnitInterval},
+		{acos, math.Acos, cmplx.Acos, inUnitInterval},
+	}
+	for _, f := range floatFuncs {
+		fn := f
+		addOperator(opMap,
+			&Operator{
+				symbol:      fn.symbol,
+				minArgCount: 1,
+				maxArgCount: 1,
+				handler: func(env *LangEnv, operands []Atom) Atom {
+					var retVal Atom
+					_, retVal.Err = checkArgTypes(fn.symbol, &operands, numTypes)
+					if retVal.Err != nil {
+						return retVal
+					}
+					retVal.Val, retVal.Err = fn.apply(operands[0].Val)
+					return retVal
+				},
+			},
+		)
+	}
+
+	// (log x) is the natural logarithm of x, and (log x b) is the logarithm
+	// of x to the base b.
+	lnFunc := floatFunc{log, math.Log, cmplx.Log, func(x float64) bool { return x >= 0 }}
+	addOperator(opMap,
+		&Operator{
+			symbol:      log,
+			minArgCount: 1,
+			maxArgCount: 2,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				_, retVal.Err = checkArgTypes(log, &operands, numTypes)
+				if retVal.Err != nil {
+					return retVal
+				}
+				logs := make([]Value, 0, len(operands))
+				for _, o := range operands {
+					l, err := lnFunc.apply(o.Val)
+					if err != nil {
+						retVal.Err = err
+						return retVal
+					}
+					logs = append(logs, l)
+				}
+				retVal.Val = logs[0]
+				if len(logs) == 1 {
+					return retVal
+				}
+
+				x, xIsFloat := logs[0].(floatValue)
+				b, bIsFloat := logs[1].(floatValue)
+				if xIsFloat && bIsFloat {
+					retVal.Val = newFloatValue(x.value / b.value)
+					return retVal
+				}
+				cx, _ := toComplex(logs[0])
+				cb, _ := toComplex(logs[1])
+				retVal.Val = newComplexValue(cx / cb)
+				return retVal
+			},
+		},
+	)
+
+	// (atan y x) is the angle of the point (x, y), like atan2.
+	atanFunc := floatFunc{atan, math.Atan, cmplx.Atan, nil}
+	addOperator(opMap,
+		&Operator{
+			symbol:      atan,
+			minArgCount: 1,
+			maxArgCount: 2,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				if len(operands) == 1 {
+					_, retVal.Err = checkArgTypes(atan, &operands, numTypes)
+					if retVal.Err != nil {
+						return retVal
+					}
+					retVal.Val, retVal.Err = atanFunc.apply(operands[0].Val)
+					return retVal
+				}
+
+				_, retVal.Err = checkArgTypes(atan, &operands, realTypes)
+				if retVal.Err != nil {
+					return retVal
+				}
+				y, _ := operands[0].Val.to(floatType)
+				x, _ := operands[1].Val.to(floatType)
+				retVal.Val = newFloatValue(math.Atan2(y.(floatValue).value, x.(floatValue).value))
+				return retVal
+			},
+		},
+	)
+
+	// (gcd) is 0 and (lcm) is 1, as those do not change the result when
+	// combined with any other number.
+	for _, symbol := range []string{gcd, lcm} {
+		divisorSymbol := symbol
+		addOperator(opMap,
+			&Operator{
+				symbol:      divisorSymbol,
+				minArgCount: 0,
+				maxArgCount: math.MaxInt32,
+				handler: func(env *LangEnv, operands []Atom) Atom {
+					var retVal Atom
+					_, retVal.Err = checkArgTypes(divisorSymbol, &operands, []valueType{intType, bigIntType})
+					if retVal.Err != nil {
+						return retVal
+					}
+					result := big.NewInt(0)
+					if divisorSymbol == lcm {
+						result.SetInt64(1)
+					}
+					for _, o := range operands {
+						n, _ := o.Val.to(bigIntType)
+						x := new(big.Int).Abs(n.(bigIntValue).value)
+						divisor := new(big.Int).GCD(nil, nil, result, x)
+						if divisorSymbol == gcd {
+							result = divisor
+						} else if x.Sign() == 0 {
+							result.SetInt64(0)
+						} else if result.Sign() != 0 {
+							result.Mul(result, x).Quo(result, divisor)
+						}
+					}
+					retVal.Val = newBigIntValue(result)
+					return retVal
+				},
+			},
+		)
+	}
+}
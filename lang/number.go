@@ -0,0 +1,279 @@
+package lang
+
+import (
+	"math"
+	"math/big"
+	"math/cmplx"
+)
+
+const (
+	// Number operators
+	numerator      string = "numerator"
+	denominator    string = "denominator"
+	exactToInexact string = "exact->inexact"
+	inexactToExact string = "inexact->exact"
+	sqrt           string = "sqrt"
+)
+
+// Returns the exact value of a number, as a fraction.
+func toRat(v Value) (*big.Rat, error) {
+	switch val := v.(type) {
+	case intValue:
+		return new(big.Rat).SetInt64(val.value), nil
+	case bigIntValue:
+		return new(big.Rat).SetInt(val.value), nil
+	case decimalValue:
+		return val.rat(), nil
+	case ratValue:
+		return val.value, nil
+	case floatValue:
+		if !isFinite(val.value) {
+			return nil, newTypeError("", []Value{v}, "%s has no exact value.", v.Str())
+		}
+		return new(big.Rat).SetFloat64(val.value), nil
+	}
+	return nil, typeConvError(v.getValueType(), ratType)
+}
+
+func isNumber(v Value) bool {
+	switch v.(type) {
+	case intValue, bigIntValue, decimalValue, ratValue, floatValue, complexValue:
+		return true
+	}
+	return false
+}
+
+// This method compares two floats. The second return value is false if they
+// can not be ordered, because one of them is NaN.
+func compareFloats(x, y float64) (int, bool) {
+	switch {
+	case math.IsNaN(x) || math.IsNaN(y):
+		return 0, false
+	case x < y:
+		return -1, true
+	case x > y:
+		return 1, true
+	}
+	return 0, true
+}
+
+func isFinite(f float64) bool {
+	return !math.IsInf(f, 0) && !math.IsNaN(f)
+}
+
+// This method compares two real numbers, which can be of different types.
+// They are compared by their exact values, so a float is not rounded to a
+// big integer, nor the other way round. The second return value is false if
+// they can not be ordered, because one of them is NaN.
+func compareReals(a, b Value) (int, bool) {
+	if x, ok := a.(intValue); ok {
+		if y, ok := b.(intValue); ok {
+			switch {
+			case x.value < y.value:
+				return -1, true
+			case x.value > y.value:
+				return 1, true
+			}
+			return 0, true
+		}
+	}
+
+	x, xIsFloat := a.(floatValue)
+	y, yIsFloat := b.(floatValue)
+	// Infinities and NaN have no exact value, but can be compared as floats.
+	if (xIsFloat && yIsFloat) || (xIsFloat && !isFinite(x.value)) || (yIsFloat && !isFinite(y.value)) {
+		xf, _ := a.to(floatType)
+		yf, _ := b.to(floatType)
+		return compareFloats(xf.(floatValue).value, yf.(floatValue).value)
+	}
+	xr, _ := toRat(a)
+	yr, _ := toRat(b)
+	return xr.Cmp(yr), true
+}
+
+// Returns true if the numbers have the same value.
+func numbersEqual(a, b Value) bool {
+	_, aIsComplex := a.(complexValue)
+	_, bIsComplex := b.(complexValue)
+	if aIsComplex || bIsComplex {
+		x, _ := toComplex(a)
+		y, _ := toComplex(b)
+		return x == y
+	}
+	c, ordered := compareReals(a, b)
+	return ordered && c == 0
+}
+
+// Returns the absolute value of a real number, of the same type.
+func absValue(v Value) Value {
+	switch val := v.(type) {
+	case intValue:
+		if val.value == math.MinInt64 {
+			var bigVal bigIntValue
+			bigVal.value = new(big.Int).Neg(big.NewInt(val.value))
+			return bigVal
+		}
+		if val.value < 0 {
+			val.value = -val.value
+		}
+		return val
+	case bigIntValue:
+		var absVal bigIntValue
+		absVal.value = new(big.Int).Abs(val.value)
+		return absVal
+	case decimalValue:
+		return newDecimalValue(new(big.Int).Abs(val.unscaled), val.scale)
+	case ratValue:
+		var absVal ratValue
+		absVal.value = new(big.Rat).Abs(val.value)
+		return absVal
+	case floatValue:
+		val.value = math.Abs(val.value)
+		return val
+	}
+	return v
+}
+
+// Returns the integer square root of n, which must not be negative, and
+// whether it is exact.
+func isqrt(n *big.Int) (*big.Int, bool) {
+	root := new(big.Int).Sqrt(n)
+	return root, new(big.Int).Mul(root, root).Cmp(n) == 0
+}
+
+// Returns the square root of a number. Exact numbers which are perfect
+// squares, like 4 or 9/4, have exact roots, and decimals are rounded to the
+// precision of the context. Negative numbers have complex roots.
+func sqrtValue(c decimalContext, v Value) (Value, error) {
+	switch val := v.(type) {
+	case complexValue:
+		return newComplexValue(cmplx.Sqrt(val.value)), nil
+	case decimalValue:
+		if val.unscaled.Sign() >= 0 {
+			return c.sqrt(val), nil
+		}
+	case intValue, bigIntValue, ratValue:
+		r, err := toRat(v)
+		if err != nil {
+			return nil, err
+		}
+		if r.Sign() >= 0 {
+			num, numExact := isqrt(r.Num())
+			den, denExact := isqrt(r.Denom())
+			if numExact && denExact {
+				return newRatValue(new(big.Rat).SetFrac(num, den)), nil
+			}
+		}
+	}
+
+	f, err := v.to(floatType)
+	if err != nil {
+		return nil, err
+	}
+	x := f.(floatValue).value
+	if x < 0 {
+		return newComplexValue(complex(0, math.Sqrt(-x))), nil
+	}
+	var val floatValue
+	val.value = math.Sqrt(x)
+	return val, nil
+}
+
+func addNumberOperators(opMap map[string]*Operator) {
+	numTypes := []valueType{intType, bigIntType, decimalType, ratType, floatType}
+
+	// numerator and denominator are the ones of the fraction in its lowest
+	// terms. For a float, they are floats as well.
+	for _, symbol := range []string{numerator, denominator} {
+		fracSymbol := symbol
+		addOperator(opMap,
+			&Operator{
+				symbol:      fracSymbol,
+				minArgCount: 1,
+				maxArgCount: 1,
+				handler: func(env *LangEnv, operands []Atom) Atom {
+					var retVal Atom
+					_, retVal.Err = checkArgTypes(fracSymbol, &operands, numTypes)
+					if retVal.Err != nil {
+						return retVal
+					}
+					r, err := toRat(operands[0].Val)
+					if err != nil {
+						retVal.Err = err
+						return retVal
+					}
+					part := new(big.Rat).SetInt(r.Num())
+					if fracSymbol == denominator {
+						part.SetInt(r.Denom())
+					}
+					retVal.Val = newRatValue(part)
+					if operands[0].Val.getValueType() == floatType {
+						retVal.Val, retVal.Err = retVal.Val.to(floatType)
+					}
+					return retVal
+				},
+			},
+		)
+	}
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      exactToInexact,
+			minArgCount: 1,
+			maxArgCount: 1,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				_, retVal.Err = checkArgTypes(exactToInexact, &operands, numTypes)
+				if retVal.Err != nil {
+					return retVal
+				}
+				retVal.Val, retVal.Err = operands[0].Val.to(floatType)
+				return retVal
+			},
+		},
+	)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      inexactToExact,
+			minArgCount: 1,
+			maxArgCount: 1,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				_, retVal.Err = checkArgTypes(inexactToExact, &operands, numTypes)
+				if retVal.Err != nil {
+					return retVal
+				}
+				// Numbers other than floats, like decimals, are exact already.
+				if operands[0].Val.getValueType() != floatType {
+					retVal.Val = operands[0].Val
+					return retVal
+				}
+				r, err := toRat(operands[0].Val)
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				retVal.Val = newRatValue(r)
+				return retVal
+			},
+		},
+	)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      sqrt,
+			minArgCount: 1,
+			maxArgCount: 1,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				_, retVal.Err = checkArgTypes(sqrt, &operands, append(numTypes, complexType))
+				if retVal.Err != nil {
+					return retVal
+				}
+				retVal.Val, retVal.Err = sqrtValue(env.global().decimals, operands[0].Val)
+				return retVal
+			},
+		},
+	)
+}
@@ -2,11 +2,9 @@ package lang
 
 import (
 	"bytes"
-	"errors"
 	"fmt"
 	"math"
 	"math/big"
-	"strings"
 )
 
 type Operator struct {
@@ -16,40 +14,124 @@ type Operator struct {
 	doNotResolveVars bool
 	passRawAST       bool
 	handler          (func(*LangEnv, []Atom) Atom)
+	// Only set for macros. Expands a call to the macro, given as data, into
+	// the expression it stands for.
+	expander func(*LangEnv, Value) (Value, *LangEnv, error)
 }
 
 const (
 	// Operators
-	add   string = "+"
-	sub   string = "-"
-	mul   string = "*"
-	div   string = "/"
-	def   string = "defvar"
-	eq    string = "="
-	gt    string = ">"
-	geq   string = ">="
-	lt    string = "<"
-	leq   string = "<="
-	and   string = "and"
-	or    string = "or"
-	defun string = "defun"
-	cond  string = "cond"
+	add      string = "+"
+	sub      string = "-"
+	mul      string = "*"
+	div      string = "/"
+	def      string = "defvar"
+	define   string = "define"
+	setVar   string = "set!"
+	eq       string = "="
+	gt       string = ">"
+	geq      string = ">="
+	lt       string = "<"
+	leq      string = "<="
+	and      string = "and"
+	or       string = "or"
+	not      string = "not"
+	defun    string = "defun"
+	cond     string = "cond"
+	ifSymbol string = "if"
+	when     string = "when"
+	unless   string = "unless"
+	lambda   string = "lambda"
+	begin    string = "begin"
+	progn    string = "progn"
+	let      string = "let"
+	letSeq   string = "let*"
+	letRec   string = "letrec"
+	quote    string = "quote"
+
+	// Keywords in cond clauses
+	elseClause string = "else"
+	arrow      string = "=>"
 )
 
 func addOperator(opMap map[string]*Operator, op *Operator) {
 	opMap[op.symbol] = op
 }
 
+// Returns true if the values are equal, the way = compares them. Numbers are
+// equal if they have the same value, and lists, vectors and hash maps if they
+// have equal elements. Procedures are only equal to themselves, however alike
+// they look, and operators like car if they are the same operator. Anything
+// else has to be of the same type, and look the same.
+func valuesEqual(env *LangEnv, a, b Value) bool {
+	for {
+		if isNumber(a) && isNumber(b) {
+			return numbersEqual(a, b)
+		}
+		if a.getValueType() != b.getValueType() {
+			return false
+		}
+		// Lists are compared along their cdrs without recursing, so that
+		// long lists do not grow the stack.
+		pair, ok := a.(pairValue)
+		if !ok {
+			break
+		}
+		other := b.(pairValue)
+		if !valuesEqual(env, pair.car, other.car) {
+			return false
+		}
+		a, b = pair.cdr, other.cdr
+	}
+
+	switch val := a.(type) {
+	case vectorValue:
+		other := b.(vectorValue)
+		if len(val.elems) != len(other.elems) {
+			return false
+		}
+		for i := range val.elems {
+			if !valuesEqual(env, val.elems[i], other.elems[i]) {
+				return false
+			}
+		}
+		return true
+	case hashMapValue:
+		other := b.(hashMapValue)
+		if len(val.entries) != len(other.entries) {
+			return false
+		}
+		for key, entry := range val.entries {
+			otherEntry, ok := other.entries[key]
+			if !ok || !valuesEqual(env, entry.value, otherEntry.value) {
+				return false
+			}
+		}
+		return true
+	case lambdaValue:
+		return val.id == b.(lambdaValue).id
+	case varValue:
+		op := env.getOperator(val.varName)
+		return op != nil && op == env.getOperator(b.(varValue).varName)
+	}
+	return a.Str() == b.Str()
+}
+
 func addBuiltinOperators(opMap map[string]*Operator) {
-	numValPrecedenceMap := map[valueType]int{intType: 1, bigIntType: 2, floatType: 3}
+	// Numbers of different types are converted to the type which comes last
+	// here. Rationals come after decimals, as a fraction like 1/3 has no exact
+	// decimal value, so (+ 1m 1/3) is exactly 4/3, rather than being rounded.
+	// Floats and complex numbers are inexact, so they come last.
+	numValPrecedenceMap := map[valueType]int{intType: 1, bigIntType: 2, decimalType: 3, ratType: 4, floatType: 5, complexType: 6}
+	// Complex numbers can not be ordered, so they are left out of comparisons.
+	realTypes := []valueType{intType, bigIntType, decimalType, ratType, floatType}
 	strValPrecedenceMap := map[valueType]int{stringType: 1}
-	boolValPrecedenceMap := map[valueType]int{boolType: 1}
 
 	addOperator(opMap,
 		&Operator{
 			symbol:      add,
 			minArgCount: 2,
-			maxArgCount: 100,
+			maxArgCount: math.MaxInt32,
 			handler: func(env *LangEnv, operands []Atom) Atom {
 				var retVal Atom
 				var finalType valueType
@@ -98,7 +180,25 @@ func addBuiltinOperators(opMap map[string]*Operator) {
 							fmt.Errorf("Error while converting %s to bigIntValue\n", o.Val.Str())
 						}
 					}
-					retVal.Val = finalVal
+					retVal.Val = newBigIntValue(finalVal.value)
+					break
+
+				case decimalType:
+					finalVal := newDecimalValue(new(big.Int), 0)
+					for _, o := range operands {
+						v, _ := o.Val.(decimalValue)
+						finalVal = finalVal.add(v)
+					}
+					retVal.Val = env.global().decimals.round(finalVal)
+					break
+
+				case ratType:
+					finalVal := new(big.Rat)
+					for _, o := range operands {
+						v, _ := o.Val.(ratValue)
+						finalVal.Add(finalVal, v.value)
+					}
+					retVal.Val = newRatValue(finalVal)
 					break
 
 				case floatType:
@@ -115,17 +215,25 @@ func addBuiltinOperators(opMap map[string]*Operator) {
 					retVal.Val = finalVal
 					break
 
+				case complexType:
+					var finalVal complex128
+					for _, o := range operands {
+						v, _ := o.Val.(complexValue)
+						finalVal += v.value
+					}
+					retVal.Val = newComplexValue(finalVal)
+					break
+
 				case stringType:
 					var buffer bytes.Buffer
-					var finalVal stringValue
 					for _, o := range operands {
 						v, ok := o.Val.(stringValue)
 						if ok {
-							buffer.WriteString(strings.Split(v.value, "\"")[1])
+							buffer.WriteString(v.value)
 						}
 					}
 
-					retVal.Val = finalVal.newValue(fmt.Sprintf("\"%s\"", buffer.String()))
+					retVal.Val = newStringValue(buffer.String())
 					break
 				}
 				return retVal
@@ -196,7 +304,19 @@ func addBuiltinOperators(opMap map[string]*Operator) {
 						fmt.Errorf("Error while converting %s to bigIntValue\n", operands[1].Val.Str())
 					}
 					finalVal.value.Sub(val1.value, val2.value)
-					retVal.Val = finalVal
+					retVal.Val = newBigIntValue(finalVal.value)
+					break
+
+				case decimalType:
+					val1, _ := operands[0].Val.(decimalValue)
+					val2, _ := operands[1].Val.(decimalValue)
+					retVal.Val = env.global().decimals.round(val1.sub(val2))
+					break
+
+				case ratType:
+					val1, _ := operands[0].Val.(ratValue)
+					val2, _ := operands[1].Val.(ratValue)
+					retVal.Val = newRatValue(new(big.Rat).Sub(val1.value, val2.value))
 					break
 
 				case floatType:
@@ -220,6 +340,12 @@ func addBuiltinOperators(opMap map[string]*Operator) {
 					finalVal.value = val1.value - val2.value
 					retVal.Val = finalVal
 					break
+
+				case complexType:
+					val1, _ := operands[0].Val.(complexValue)
+					val2, _ := operands[1].Val.(complexValue)
+					retVal.Val = newComplexValue(val1.value - val2.value)
+					break
 				}
 				return retVal
 			},
@@ -230,7 +356,7 @@ func addBuiltinOperators(opMap map[string]*Operator) {
 		&Operator{
 			symbol:      mul,
 			minArgCount: 2,
-			maxArgCount: 100,
+			maxArgCount: math.MaxInt32,
 			handler: func(env *LangEnv, operands []Atom) Atom {
 				var retVal Atom
 				var finalType valueType
@@ -290,7 +416,25 @@ func addBuiltinOperators(opMap map[string]*Operator) {
 							fmt.Errorf("Error while converting %s to bigIntValue\n", o.Val.Str())
 						}
 					}
-					retVal.Val = finalVal
+					retVal.Val = newBigIntValue(finalVal.value)
+					break
+
+				case decimalType:
+					finalVal := newDecimalValue(big.NewInt(1), 0)
+					for _, o := range operands {
+						v, _ := o.Val.(decimalValue)
+						finalVal = finalVal.mul(v)
+					}
+					retVal.Val = env.global().decimals.round(finalVal)
+					break
+
+				case ratType:
+					finalVal := new(big.Rat).SetInt64(1)
+					for _, o := range operands {
+						v, _ := o.Val.(ratValue)
+						finalVal.Mul(finalVal, v.value)
+					}
+					retVal.Val = newRatValue(finalVal)
 					break
 
 				case floatType:
@@ -306,6 +450,15 @@ func addBuiltinOperators(opMap map[string]*Operator) {
 					}
 					retVal.Val = finalVal
 					break
+
+				case complexType:
+					finalVal := complex128(1)
+					for _, o := range operands {
+						v, _ := o.Val.(complexValue)
+						finalVal *= v.value
+					}
+					retVal.Val = newComplexValue(finalVal)
+					break
 				}
 				return retVal
 			},
@@ -354,19 +507,21 @@ func addBuiltinOperators(opMap map[string]*Operator) {
 							}
 						}
 
+						// Division of integers is exact, so it might give a fraction.
+						if val1.value%val2.value != 0 {
+							tryTypeCastTo(&operands, ratType)
+							finalType = ratType
+							goto performOp
+						}
 						finalVal.value = val1.value / val2.value
 						retVal.Val = finalVal
 					} else {
-						retVal.Err = errors.New(fmt.Sprintf("divide by zero"))
+						retVal.Err = newDivideByZeroError(div, []Value{val1, val2})
 					}
 					break
 
 				case bigIntType:
-					var finalVal bigIntValue
 					var val1, val2 bigIntValue
-					finalVal.value = new(big.Int)
-					finalVal.value.SetInt64(1)
-
 					var ok bool
 					val1, ok = operands[0].Val.(bigIntValue)
 					if !ok {
@@ -377,11 +532,30 @@ func addBuiltinOperators(opMap map[string]*Operator) {
 					if !ok {
 						fmt.Errorf("Error while converting %s to bigIntValue\n", operands[1].Val.Str())
 					}
-					if val2.value.Cmp(new(big.Int).SetInt64(0)) != 0 {
-						finalVal.value.Div(val1.value, val2.value)
-						retVal.Val = finalVal
+					if val2.value.Sign() != 0 {
+						retVal.Val = newRatValue(new(big.Rat).SetFrac(val1.value, val2.value))
+					} else {
+						retVal.Err = newDivideByZeroError(div, []Value{val1, val2})
+					}
+					break
+
+				case decimalType:
+					val1, _ := operands[0].Val.(decimalValue)
+					val2, _ := operands[1].Val.(decimalValue)
+					if val2.unscaled.Sign() != 0 {
+						retVal.Val = env.global().decimals.quo(val1, val2)
+					} else {
+						retVal.Err = newDivideByZeroError(div, []Value{val1, val2})
+					}
+					break
+
+				case ratType:
+					val1, _ := operands[0].Val.(ratValue)
+					val2, _ := operands[1].Val.(ratValue)
+					if val2.value.Sign() != 0 {
+						retVal.Val = newRatValue(new(big.Rat).Quo(val1.value, val2.value))
 					} else {
-						retVal.Err = errors.New(fmt.Sprintf("divide by zero"))
+						retVal.Err = newDivideByZeroError(div, []Value{val1, val2})
 					}
 					break
 
@@ -404,7 +578,17 @@ func addBuiltinOperators(opMap map[string]*Operator) {
 						finalVal.value = val1.value / val2.value
 						retVal.Val = finalVal
 					} else {
-						retVal.Err = errors.New(fmt.Sprintf("divide by zero"))
+						retVal.Err = newDivideByZeroError(div, []Value{val1, val2})
+					}
+					break
+
+				case complexType:
+					val1, _ := operands[0].Val.(complexValue)
+					val2, _ := operands[1].Val.(complexValue)
+					if val2.value != 0 {
+						retVal.Val = newComplexValue(val1.value / val2.value)
+					} else {
+						retVal.Err = newDivideByZeroError(div, []Value{val1, val2})
 					}
 					break
 				}
@@ -425,22 +609,24 @@ func addBuiltinOperators(opMap map[string]*Operator) {
 				vtype2 := operands[1].Val.getValueType()
 
 				if vtype1 != varType {
-					retVal.Err = errors.New(fmt.Sprintf("For %s, expected %s to be %s, but was %s", def, operands[0].Val.Str(), varType, vtype1))
+					retVal.Err = newTypeError(def, []Value{operands[0].Val},
+						"For %s, expected %s to be %s, but was %s", def, operands[0].Val.Str(), varType, vtype1)
 					return retVal
 				}
 
 				if vtype2 == varType {
-					retVal.Err = errors.New(fmt.Sprintf("For %s, expected %s to not be %s, but was.", def, operands[1].Val.Str(), varType))
+					retVal.Err = newTypeError(def, []Value{operands[1].Val},
+						"For %s, expected %s to not be %s, but was.", def, operands[1].Val.Str(), varType)
 					return retVal
 				}
 
 				sym := operands[0].Val.Str()
 				if env.getOperator(sym) != nil {
-					retVal.Err = errors.New(fmt.Sprintf("Cannot use %s as a variable, as it is defined as an operator.", sym))
+					retVal.Err = newEvalError("Cannot use %s as a variable, as it is defined as an operator.", sym)
 					return retVal
 				}
 
-				env.varMap[sym] = operands[1].Val
+				env.definitionFrame().varMap[sym] = operands[1].Val
 				retVal.Val = operands[1].Val
 				return retVal
 			},
@@ -449,60 +635,51 @@ func addBuiltinOperators(opMap map[string]*Operator) {
 
 	addOperator(opMap,
 		&Operator{
-			symbol:      eq,
+			symbol:      define,
 			minArgCount: 2,
-			maxArgCount: 2,
+			maxArgCount: math.MaxInt32,
+			passRawAST:  true,
 			handler: func(env *LangEnv, operands []Atom) Atom {
 				var retVal Atom
-				vtype1 := operands[0].Val.getValueType()
-				vtype2 := operands[1].Val.getValueType()
-
-				if vtype1 != vtype2 {
-					retVal.Err = errors.New(fmt.Sprintf("Cannot use %s operator for two different types %s and %s", eq, vtype1, vtype2))
+				astVal, _ := operands[0].Val.(astValue)
+				nameNode := astVal.astNodes[0]
+				// (define (name params ...) body ...) defines a method.
+				isMethod := !nameNode.isValue && len(nameNode.children) > 0
+				if isMethod {
+					nameNode = nameNode.children[0]
+				}
+				name, err := getVarName(env, nameNode, define)
+				if err != nil {
+					retVal.Err = err
 					return retVal
 				}
-
-				retVal.Val = newBoolValue(operands[0].Val.Str() == operands[1].Val.Str())
-				return retVal
-			},
-		},
-	)
-
-	addOperator(opMap,
-		&Operator{
-			symbol:      gt,
-			minArgCount: 2,
-			maxArgCount: 2,
-			handler: func(env *LangEnv, operands []Atom) Atom {
-				var retVal Atom
-				var finalType valueType
-				finalType, retVal.Err = chainedTypeCoerce(gt, &operands, []map[valueType]int{numValPrecedenceMap, strValPrecedenceMap})
-				if retVal.Err != nil {
+				if env.getOperator(name) != nil {
+					retVal.Err = newEvalError("Cannot use %s as a variable, as it is defined as an operator.", name)
 					return retVal
 				}
 
-				switch finalType {
-				case intType:
-					var val1, val2 intValue
-					val1, _ = operands[0].Val.(intValue)
-					val2, _ = operands[1].Val.(intValue)
-					retVal.Val = newBoolValue(val1.value > val2.value)
-					break
-
-				case floatType:
-					var val1, val2 floatValue
-					val1, _ = operands[0].Val.(floatValue)
-					val2, _ = operands[1].Val.(floatValue)
-					retVal.Val = newBoolValue(val1.value > val2.value)
-					break
-
-				case stringType:
-					var val1, val2 stringValue
-					val1, _ = operands[0].Val.(stringValue)
-					val2, _ = operands[1].Val.(stringValue)
-					retVal.Val = newBoolValue(val1.value > val2.value)
-					break
+				if isMethod {
+					paramsNode := new(ASTNode)
+					paramsNode.children = astVal.astNodes[0].children[1:]
+					params, rest, err := getParams(env, paramsNode, name)
+					if err != nil {
+						retVal.Err = err
+						return retVal
+					}
+					method := newLambdaValue(env, params, rest, astVal.astNodes[1:])
+					method.name = name
+					retVal.Val = method
+				} else {
+					if len(astVal.astNodes) != 2 {
+						retVal.Err = newArityError(define, len(astVal.astNodes), 2, 2)
+						return retVal
+					}
+					retVal = evalASTHelper(env, astVal.astNodes[1])
+					if retVal.Err != nil {
+						return retVal
+					}
 				}
+				env.definitionFrame().bindValue(name, retVal.Val, env)
 				return retVal
 			},
 		},
@@ -510,226 +687,179 @@ func addBuiltinOperators(opMap map[string]*Operator) {
 
 	addOperator(opMap,
 		&Operator{
-			symbol:      geq,
+			symbol:      setVar,
 			minArgCount: 2,
 			maxArgCount: 2,
+			passRawAST:  true,
 			handler: func(env *LangEnv, operands []Atom) Atom {
 				var retVal Atom
-				var finalType valueType
-				finalType, retVal.Err = chainedTypeCoerce(gt, &operands, []map[valueType]int{numValPrecedenceMap, strValPrecedenceMap})
-				if retVal.Err != nil {
+				astVal, _ := operands[0].Val.(astValue)
+				name, err := getVarName(env, astVal.astNodes[0], setVar)
+				if err != nil {
+					retVal.Err = err
 					return retVal
 				}
 
-				switch finalType {
-				case intType:
-					var val1, val2 intValue
-					val1, _ = operands[0].Val.(intValue)
-					val2, _ = operands[1].Val.(intValue)
-					retVal.Val = newBoolValue(val1.value >= val2.value)
-					break
-
-				case floatType:
-					var val1, val2 floatValue
-					val1, _ = operands[0].Val.(floatValue)
-					val2, _ = operands[1].Val.(floatValue)
-					retVal.Val = newBoolValue(val1.value >= val2.value)
-					break
-
-				case stringType:
-					var val1, val2 stringValue
-					val1, _ = operands[0].Val.(stringValue)
-					val2, _ = operands[1].Val.(stringValue)
-					retVal.Val = newBoolValue(val1.value >= val2.value)
-					break
+				// The binding is changed in the frame it was made in, so that
+				// everyone who can see it sees the new value.
+				frame, boundName := env.bindingFrame(name)
+				if frame == nil {
+					retVal.Err = newUndefinedError(name, false)
+					return retVal
 				}
-				return retVal
-			},
-		},
-	)
-
-	addOperator(opMap,
-		&Operator{
-			symbol:      lt,
-			minArgCount: 2,
-			maxArgCount: 2,
-			handler: func(env *LangEnv, operands []Atom) Atom {
-				var retVal Atom
-				var finalType valueType
-				finalType, retVal.Err = chainedTypeCoerce(gt, &operands, []map[valueType]int{numValPrecedenceMap, strValPrecedenceMap})
-				if retVal.Err != nil {
+				if frame.parent == nil && frame.opMap[boundName] != nil {
+					retVal.Err = newEvalError("Cannot use %s on %s, as it is defined as an operator.", setVar, name)
 					return retVal
 				}
 
-				switch finalType {
-				case intType:
-					var val1, val2 intValue
-					val1, _ = operands[0].Val.(intValue)
-					val2, _ = operands[1].Val.(intValue)
-					retVal.Val = newBoolValue(val1.value < val2.value)
-					break
-
-				case floatType:
-					var val1, val2 floatValue
-					val1, _ = operands[0].Val.(floatValue)
-					val2, _ = operands[1].Val.(floatValue)
-					retVal.Val = newBoolValue(val1.value < val2.value)
-					break
-
-				case stringType:
-					var val1, val2 stringValue
-					val1, _ = operands[0].Val.(stringValue)
-					val2, _ = operands[1].Val.(stringValue)
-					retVal.Val = newBoolValue(val1.value < val2.value)
-					break
+				retVal = evalASTHelper(env, astVal.astNodes[1])
+				if retVal.Err != nil {
+					return retVal
 				}
+				frame.bindValue(boundName, retVal.Val, env)
 				return retVal
 			},
 		},
 	)
 
+	// = is true if all its operands are equal. Numbers are equal if they have
+	// the same value, whatever their types, so (= 1 1.0) is true. Anything else
+	// has to be of the same type, and is compared by valuesEqual.
 	addOperator(opMap,
 		&Operator{
-			symbol:      leq,
+			symbol:      eq,
 			minArgCount: 2,
-			maxArgCount: 2,
+			maxArgCount: math.MaxInt32,
 			handler: func(env *LangEnv, operands []Atom) Atom {
 				var retVal Atom
-				var finalType valueType
-				finalType, retVal.Err = chainedTypeCoerce(gt, &operands, []map[valueType]int{numValPrecedenceMap, strValPrecedenceMap})
-				if retVal.Err != nil {
-					return retVal
-				}
-
-				switch finalType {
-				case intType:
-					var val1, val2 intValue
-					val1, _ = operands[0].Val.(intValue)
-					val2, _ = operands[1].Val.(intValue)
-					retVal.Val = newBoolValue(val1.value <= val2.value)
-					break
-
-				case floatType:
-					var val1, val2 floatValue
-					val1, _ = operands[0].Val.(floatValue)
-					val2, _ = operands[1].Val.(floatValue)
-					retVal.Val = newBoolValue(val1.value <= val2.value)
-					break
+				equal := true
+				for i := 1; i < len(operands); i++ {
+					val1 := operands[i-1].Val
+					val2 := operands[i].Val
+					if isNumber(val1) && isNumber(val2) {
+						equal = equal && numbersEqual(val1, val2)
+						continue
+					}
 
-				case stringType:
-					var val1, val2 stringValue
-					val1, _ = operands[0].Val.(stringValue)
-					val2, _ = operands[1].Val.(stringValue)
-					retVal.Val = newBoolValue(val1.value <= val2.value)
-					break
+					vtype1 := val1.getValueType()
+					vtype2 := val2.getValueType()
+					if vtype1 != vtype2 {
+						retVal.Err = newTypeError(eq, []Value{val1, val2},
+							"Cannot use %s operator for two different types %s and %s", eq, vtype1, vtype2)
+						return retVal
+					}
+					equal = equal && valuesEqual(env, val1, val2)
 				}
+				retVal.Val = newBoolValue(equal)
 				return retVal
 			},
 		},
 	)
 
+	// The comparisons are chained, so (< a b c) is true if a < b and b < c.
+	// They work on strings, which are collated ignoring case first, on
+	// characters, or on real numbers of any types, which are compared exactly.
+	comparisons := map[string]func(int) bool{
+		gt:  func(c int) bool { return c > 0 },
+		geq: func(c int) bool { return c >= 0 },
+		lt:  func(c int) bool { return c < 0 },
+		leq: func(c int) bool { return c <= 0 },
+	}
+	for _, symbol := range []string{gt, geq, lt, leq} {
+		cmpSymbol := symbol
+		holds := comparisons[cmpSymbol]
+		addOperator(opMap,
+			&Operator{
+				symbol:      cmpSymbol,
+				minArgCount: 2,
+				maxArgCount: math.MaxInt32,
+				handler: func(env *LangEnv, operands []Atom) Atom {
+					var retVal Atom
+					allowedTypes := realTypes
+					switch operands[0].Val.getValueType() {
+					case stringType, charType:
+						allowedTypes = []valueType{operands[0].Val.getValueType()}
+					}
+					_, retVal.Err = checkArgTypes(cmpSymbol, &operands, allowedTypes)
+					if retVal.Err != nil {
+						return retVal
+					}
+
+					result := true
+					for i := 1; i < len(operands) && result; i++ {
+						c, ordered := 0, true
+						switch prev := operands[i-1].Val.(type) {
+						case stringValue:
+							c = collateStrings(prev.value, operands[i].Val.(stringValue).value)
+						case charValue:
+							c = int(prev.value - operands[i].Val.(charValue).value)
+						default:
+							c, ordered = compareReals(operands[i-1].Val, operands[i].Val)
+						}
+						result = ordered && holds(c)
+					}
+					retVal.Val = newBoolValue(result)
+					return retVal
+				},
+			},
+		)
+	}
+
 	addOperator(opMap,
 		&Operator{
 			symbol:      defun,
 			minArgCount: 3,
-			maxArgCount: 3,
+			maxArgCount: math.MaxInt32,
 			passRawAST:  true,
 			handler: func(env *LangEnv, operands []Atom) Atom {
 				var retVal Atom
 				astVal, ok := operands[0].Val.(astValue)
 				if !ok {
-					retVal.Err = errors.New(fmt.Sprintf("operand[0] has to be astValue"))
+					retVal.Err = newEvalError("operand[0] has to be astValue")
 					return retVal
 				}
 				// Check astNode[0] is of varType, and is not registered in varMap
 				if !astVal.astNodes[0].isValue {
-					retVal.Err = errors.New(fmt.Sprintf("Method name not defined correctly."))
+					retVal.Err = newSyntaxError("Method name not defined correctly.")
 					return retVal
 				}
 				methodNameVal, err := getValue(env, astVal.astNodes[0].value)
 				if err != nil || methodNameVal.getValueType() != varType {
-					retVal.Err = errors.New(fmt.Sprintf("Expecting method name, got %s", astVal.astNodes[0].value))
+					retVal.Err = newSyntaxError("Expecting method name, got %s", astVal.astNodes[0].value)
 					return retVal
 				}
 
 				methodName := methodNameVal.Str()
-				if _, ok := env.varMap[methodNameVal.Str()]; ok {
-					retVal.Err = errors.New(fmt.Sprintf("Method %s already defined as a variable", methodName))
+				if env.getValue(methodName) != nil {
+					retVal.Err = newEvalError("Method %s already defined as a variable", methodName)
 					return retVal
 				}
 
-				if _, ok := env.opMap[methodNameVal.Str()]; ok {
-					retVal.Err = errors.New(fmt.Sprintf("Method %s already defined as an operator", methodName))
+				if env.getOperator(methodName) != nil {
+					retVal.Err = newEvalError("Method %s already defined as an operator", methodName)
 					return retVal
 				}
 
-				if astVal.astNodes[1].isValue {
-					retVal.Err = errors.New(fmt.Sprintf("Missing list of parameters for method %s", methodName))
+				params, rest, err := getParams(env, astVal.astNodes[1], methodName)
+				if err != nil {
+					retVal.Err = err
 					return retVal
 				}
 
-				// Check astNode[1] is a list of varTypes.
-				params := make([]string, 0)
-				for i, node := range astVal.astNodes[1].children {
-					if !node.isValue {
-						retVal.Err = errors.New(fmt.Sprintf("Malformed parameter %d in method %s.", i, methodName))
-						return retVal
-					}
-					paramName := node.value
-					val, err := getValue(env, paramName)
-					if err != nil || val.getValueType() != varType {
-						retVal.Err = errors.New(fmt.Sprintf("Malformed parameter %s in method %s.", paramName, methodName))
-						return retVal
-					}
-					params = append(params, paramName)
+				// A method is just a named lambda, which lives in the opMap of the
+				// frame it was defined in.
+				method := newLambdaValue(env, params, rest, astVal.astNodes[2:])
+				maxArgCount := len(params)
+				if len(rest) > 0 {
+					maxArgCount = math.MaxInt32
 				}
-
-				addOperator(opMap,
+				addOperator(env.definitionFrame().opMap,
 					&Operator{
 						symbol:      methodName,
 						minArgCount: len(params),
-						maxArgCount: len(params),
+						maxArgCount: maxArgCount,
 						handler: func(env *LangEnv, operands []Atom) Atom {
-							var retVal Atom
-							maxRecursionLimit := 100000
-							newEnv := NewEnv()
-
-							// Copy all the operators of the parent env.
-							newEnv.opMap = make(map[string]*Operator, 0)
-							for k, v := range env.opMap {
-								newEnv.opMap[k] = v
-							}
-
-							// Copy all the variable values of the parent env.
-							// We will favor formal arguments over previously defined variables.
-							newEnv.varMap = make(map[string]Value, 0)
-							for k, v := range env.varMap {
-								newEnv.varMap[k] = v
-							}
-
-							// fmt.Printf("Executing the method %s with values: \n", methodName)
-							for i, p := range params {
-								// Check here whether operands[i] is a variable / operator.
-								if op, ok := opMap[operands[i].Val.Str()]; ok {
-									newEnv.opMap[p] = op
-									// fmt.Printf("%s = %s (op)\n", p, operands[i].Val.Str())
-								} else {
-									newEnv.varMap[p] = operands[i].Val
-									// fmt.Printf("%s = %s (op)\n", p, operands[i].Val.Str())
-								}
-							}
-
-							// fmt.Printf(", d: %d\n", env.recursionDepth)
-							newEnv.recursionDepth = env.recursionDepth + 1
-							if newEnv.recursionDepth > maxRecursionLimit {
-								retVal.Err = errors.New(fmt.Sprintf("Reached the recursion limit of %d. Terminating.", maxRecursionLimit))
-								return retVal
-							}
-
-							// fmt.Printf("AST structure: %s\n", getASTStr(astVal.astNodes[2]))
-							retVal = evalASTHelper(newEnv, astVal.astNodes[2])
-							// fmt.Printf("evalASTHelper returned with %s\n", retVal.Val.Str())
-							return retVal
+							return callLambda(env, method, operands)
 						},
 					},
 				)
@@ -744,24 +874,24 @@ func addBuiltinOperators(opMap map[string]*Operator) {
 
 	addOperator(opMap,
 		&Operator{
-			symbol:      and,
+			symbol:      lambda,
 			minArgCount: 2,
-			maxArgCount: 100,
+			maxArgCount: math.MaxInt32,
+			passRawAST:  true,
 			handler: func(env *LangEnv, operands []Atom) Atom {
 				var retVal Atom
-				_, retVal.Err = typeCoerce(and, &operands, boolValPrecedenceMap)
-				if retVal.Err != nil {
+				astVal, ok := operands[0].Val.(astValue)
+				if !ok {
+					retVal.Err = newEvalError("operand[0] has to be astValue")
 					return retVal
 				}
 
-				result := true
-				for _, o := range operands {
-					v, ok := o.Val.(boolValue)
-					if ok {
-						result = result && v.value
-					}
+				params, rest, err := getParams(env, astVal.astNodes[0], lambda)
+				if err != nil {
+					retVal.Err = err
+					return retVal
 				}
-				retVal.Val = newBoolValue(result)
+				retVal.Val = newLambdaValue(env, params, rest, astVal.astNodes[1:])
 				return retVal
 			},
 		},
@@ -769,24 +899,144 @@ func addBuiltinOperators(opMap map[string]*Operator) {
 
 	addOperator(opMap,
 		&Operator{
-			symbol:      or,
-			minArgCount: 2,
-			maxArgCount: 100,
+			symbol:      quote,
+			minArgCount: 1,
+			maxArgCount: 1,
+			passRawAST:  true,
 			handler: func(env *LangEnv, operands []Atom) Atom {
 				var retVal Atom
-				_, retVal.Err = typeCoerce(or, &operands, boolValPrecedenceMap)
-				if retVal.Err != nil {
-					return retVal
+				astVal, _ := operands[0].Val.(astValue)
+				retVal.Val, retVal.Err = astToValue(env, astVal.astNodes[0])
+				if retVal.Err == nil {
+					retVal.Val = syntaxToDatum(env, retVal.Val)
 				}
+				return retVal
+			},
+		},
+	)
 
-				result := false
-				for _, o := range operands {
-					v, ok := o.Val.(boolValue)
-					if ok {
-						result = result || v.value
+	// let evaluates all the values before binding any of them, let* binds them
+	// one by one, and letrec binds them in an environment where all of them
+	// are visible, so that lambdas can refer to each other.
+	for _, symbol := range []string{let, letSeq, letRec} {
+		letSymbol := symbol
+		addOperator(opMap,
+			&Operator{
+				symbol:      letSymbol,
+				minArgCount: 2,
+				maxArgCount: math.MaxInt32,
+				passRawAST:  true,
+				handler: func(env *LangEnv, operands []Atom) Atom {
+					var retVal Atom
+					astVal, _ := operands[0].Val.(astValue)
+					bindingsNode := astVal.astNodes[0]
+					if bindingsNode.isValue {
+						retVal.Err = newSyntaxError(
+							"Bindings for %s should be of the format `((name value) ...)`.", letSymbol)
+						return retVal
 					}
-				}
-				retVal.Val = newBoolValue(result)
+
+					newEnv := newChildEnv(env)
+					evalEnv := newEnv
+					if letSymbol == let {
+						evalEnv = env
+					}
+
+					names := make([]string, 0)
+					values := make([]Value, 0)
+					for _, binding := range bindingsNode.children {
+						if binding.isValue || len(binding.children) != 2 || !binding.children[0].isValue {
+							retVal.Err = newSyntaxError(
+								"Bindings for %s should be of the format `((name value) ...)`.", letSymbol)
+							return retVal
+						}
+						name := binding.children[0].value
+						nameVal, err := getValue(env, name)
+						if err != nil || nameVal.getValueType() != varType {
+							retVal.Err = newSyntaxError("Malformed binding %s in %s.", name, letSymbol)
+							return retVal
+						}
+
+						v := evalASTHelper(evalEnv, binding.children[1])
+						if v.Err != nil {
+							return v
+						}
+						if letSymbol == let {
+							names = append(names, name)
+							values = append(values, v.Val)
+						} else {
+							newEnv.bindValue(name, v.Val, evalEnv)
+						}
+					}
+					for i, name := range names {
+						newEnv.bindValue(name, values[i], env)
+					}
+					return evalBody(newEnv, astVal.astNodes[1:])
+				},
+			},
+		)
+	}
+
+	// progn is the name Common Lisp uses for begin.
+	for _, symbol := range []string{begin, progn} {
+		addOperator(opMap,
+			&Operator{
+				symbol:      symbol,
+				minArgCount: 1,
+				maxArgCount: math.MaxInt32,
+				passRawAST:  true,
+				handler: func(env *LangEnv, operands []Atom) Atom {
+					astVal, _ := operands[0].Val.(astValue)
+					return evalBody(env, astVal.astNodes)
+				},
+			},
+		)
+	}
+
+	// and stops at the first operand which is false, and or at the first one
+	// which is true. Either returns the value of the operand it stopped at, or
+	// of the last operand.
+	for _, symbol := range []string{and, or} {
+		logicSymbol := symbol
+		addOperator(opMap,
+			&Operator{
+				symbol:      logicSymbol,
+				minArgCount: 0,
+				maxArgCount: math.MaxInt32,
+				passRawAST:  true,
+				handler: func(env *LangEnv, operands []Atom) Atom {
+					var retVal Atom
+					astNodeVal, _ := operands[0].Val.(astValue)
+					nodes := astNodeVal.astNodes
+					if len(nodes) == 0 {
+						retVal.Val = newBoolValue(logicSymbol == and)
+						return retVal
+					}
+
+					last := len(nodes) - 1
+					for _, node := range nodes[:last] {
+						v := evalASTHelper(env, node)
+						if v.Err != nil {
+							return v
+						}
+						if isTruthy(v.Val) != (logicSymbol == and) {
+							return v
+						}
+					}
+					return newTailCall(env, nodes[last])
+				},
+			},
+		)
+	}
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      not,
+			minArgCount: 1,
+			maxArgCount: 1,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				retVal.Val = newBoolValue(!isTruthy(operands[0].Val))
 				return retVal
 			},
 		},
@@ -796,38 +1046,114 @@ func addBuiltinOperators(opMap map[string]*Operator) {
 		&Operator{
 			symbol:      cond,
 			minArgCount: 1,
-			maxArgCount: 100,
+			maxArgCount: math.MaxInt32,
 			passRawAST:  true,
 			handler: func(env *LangEnv, operands []Atom) Atom {
 				var retVal Atom
 				astNodeVal, _ := operands[0].Val.(astValue)
 				for i, astNode := range astNodeVal.astNodes {
-					if len(astNode.children) != 2 {
-						retVal.Err = errors.New(fmt.Sprintf(
-							"Arguments for %s should be of the format `(condition value)`.",
-							cond))
+					if astNode.isValue || len(astNode.children) == 0 {
+						retVal.Err = newSyntaxError(
+							"Arguments for %s should be of the format `(condition value ...)`.",
+							cond)
 						return retVal
 					}
-					condValue := evalAST(env, astNode.children[0])
+
+					test := astNode.children[0]
+					body := astNode.children[1:]
+					if env.isKeyword(test, elseClause) {
+						if i != len(astNodeVal.astNodes)-1 {
+							retVal.Err = newSyntaxError("The %s clause has to be the last one in %s.", elseClause, cond)
+							return retVal
+						}
+						if len(body) == 0 {
+							retVal.Err = newSyntaxError("The %s clause in %s needs a body.", elseClause, cond)
+							return retVal
+						}
+						return evalBody(env, body)
+					}
+
+					condValue := evalASTHelper(env, test)
 					if condValue.Err != nil {
 						return condValue
 					}
-					if condValue.Val.getValueType() != boolType {
-						retVal.Err = errors.New(fmt.Sprintf(
-							"Arguments for operand %d for %s was of type %s instead of %s.",
-							i+1, cond, condValue.Val.getValueType(), boolType))
+					if !isTruthy(condValue.Val) {
+						continue
 					}
-					condBoolValue, _ := condValue.Val.(boolValue)
-					if condBoolValue.value {
-						return evalAST(env, astNode.children[1])
+
+					// A clause without a body, like (x), returns the value of its test.
+					if len(body) == 0 {
+						return condValue
+					}
+					// (test => method) calls the method with the value of the test.
+					if env.isKeyword(body[0], arrow) {
+						if len(body) != 2 {
+							retVal.Err = newSyntaxError(
+								"Arguments for %s should be of the format `(condition %s method)`.",
+								cond, arrow)
+							return retVal
+						}
+						return applyMethod(env, body[1], []Atom{condValue})
 					}
+					return evalBody(env, body)
+				}
+
+				// None of the conditions were true.
+				retVal.Val = emptyListValue{}
+				return retVal
+			},
+		},
+	)
 
-					retVal.Err = errors.New(fmt.Sprintf(
-						"None of the arguments for %s evaluated to true.",
-						cond))
+	addOperator(opMap,
+		&Operator{
+			symbol:      ifSymbol,
+			minArgCount: 2,
+			maxArgCount: 3,
+			passRawAST:  true,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				astNodeVal, _ := operands[0].Val.(astValue)
+				condValue := evalASTHelper(env, astNodeVal.astNodes[0])
+				if condValue.Err != nil {
+					return condValue
+				}
+				if isTruthy(condValue.Val) {
+					return newTailCall(env, astNodeVal.astNodes[1])
 				}
+				if len(astNodeVal.astNodes) == 3 {
+					return newTailCall(env, astNodeVal.astNodes[2])
+				}
+				retVal.Val = emptyListValue{}
 				return retVal
 			},
 		},
 	)
+
+	// when evaluates its body if the condition is true, and unless if it is
+	// false.
+	for _, symbol := range []string{when, unless} {
+		whenSymbol := symbol
+		addOperator(opMap,
+			&Operator{
+				symbol:      whenSymbol,
+				minArgCount: 2,
+				maxArgCount: math.MaxInt32,
+				passRawAST:  true,
+				handler: func(env *LangEnv, operands []Atom) Atom {
+					var retVal Atom
+					astNodeVal, _ := operands[0].Val.(astValue)
+					condValue := evalASTHelper(env, astNodeVal.astNodes[0])
+					if condValue.Err != nil {
+						return condValue
+					}
+					if isTruthy(condValue.Val) == (whenSymbol == when) {
+						return evalBody(env, astNodeVal.astNodes[1:])
+					}
+					retVal.Val = emptyListValue{}
+					return retVal
+				},
+			},
+		)
+	}
 }
@@ -0,0 +1,123 @@
+package lang
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// A Span is the part of a source an expression was read from. Lines and
+// columns start at 1, and EndCol is the column just after the expression.
+type Span struct {
+	File    string
+	Line    int
+	Col     int
+	EndLine int
+	EndCol  int
+}
+
+func (s Span) String() string {
+	if len(s.File) > 0 {
+		return fmt.Sprintf("%s:%d:%d", s.File, s.Line, s.Col)
+	}
+	return fmt.Sprintf("%d:%d", s.Line, s.Col)
+}
+
+// Returns the span from the start of the first span to the end of the second.
+func joinSpans(start, end Span) Span {
+	start.EndLine = end.EndLine
+	start.EndCol = end.EndCol
+	return start
+}
+
+// A Source is a piece of program text, like a script file, or a line typed
+// into the REPL. The expressions in it can be evaluated one after the other.
+type Source struct {
+	Name   string
+	Text   string
+	tokens []token
+	err    error
+}
+
+func NewSource(name, text string) *Source {
+	src := new(Source)
+	src.Name = name
+	src.Text = text
+	src.tokens, src.err = tokenize(name, text)
+	return src
+}
+
+// Returns true if there is anything left to evaluate in the source.
+func (s *Source) More() bool {
+	return s.err != nil || len(s.tokens) > 0
+}
+
+// Reads the next expression from the source. The node is nil if there was
+// nothing but comments left.
+func (s *Source) read() (*ASTNode, error) {
+	if s.err != nil {
+		err := s.err
+		s.err = nil
+		return nil, err
+	}
+
+	var err error
+	s.tokens, err = skipDatumComments(s.tokens)
+	if err != nil || len(s.tokens) == 0 {
+		s.tokens = nil
+		return nil, err
+	}
+
+	node, tokens, err := buildAST(s.tokens)
+	if err != nil {
+		// There is no telling where the next expression starts.
+		s.tokens = nil
+		return nil, err
+	}
+	s.tokens = tokens
+	return node, nil
+}
+
+// Returns the tokens which have not been read yet, separated by spaces.
+func (s *Source) remainingTokens() string {
+	var buffer bytes.Buffer
+	for _, t := range s.tokens {
+		buffer.WriteString(t.text)
+		buffer.WriteString(" ")
+	}
+	return strings.TrimSpace(buffer.String())
+}
+
+// Returns the line a span starts on, followed by a line which underlines the
+// span, like so:
+//
+//	(+ 1 (foo 2))
+//	     ^~~~~~~
+func (s *Source) Excerpt(span Span) string {
+	lines := strings.Split(s.Text, "\n")
+	if span.Line < 1 || span.Line > len(lines) {
+		return ""
+	}
+	line := []rune(strings.TrimRight(lines[span.Line-1], "\r"))
+
+	var buffer bytes.Buffer
+	buffer.WriteString(string(line))
+	buffer.WriteString("\n")
+	// Tabs are kept, so that the caret lines up with the line above.
+	for i := 0; i < span.Col-1 && i < len(line); i++ {
+		if line[i] == '\t' {
+			buffer.WriteRune('\t')
+		} else {
+			buffer.WriteRune(' ')
+		}
+	}
+	buffer.WriteString("^")
+	end := len(line) + 1
+	if span.EndLine == span.Line {
+		end = span.EndCol
+	}
+	for i := span.Col + 1; i < end; i++ {
+		buffer.WriteString("~")
+	}
+	return buffer.String()
+}
@@ -0,0 +1,506 @@
+package lang
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// String operators
+	stringLength   string = "string-length"
+	substring      string = "substring"
+	stringAppend   string = "string-append"
+	stringUpcase   string = "string-upcase"
+	stringDowncase string = "string-downcase"
+	stringSplit    string = "string-split"
+	stringJoin     string = "string-join"
+	stringIndex    string = "string-index"
+	stringReplace  string = "string-replace"
+	stringTrim     string = "string-trim"
+	stringToNumber string = "string->number"
+	numberToString string = "number->string"
+	format         string = "format"
+
+	// Output operators
+	display string = "display"
+	write   string = "write"
+	newline string = "newline"
+)
+
+// Returns the value the way display prints it. Str gives strings the way they
+// are read, in quotes and with escapes, whereas this gives their contents as
+// they are, even inside lists.
+func displayString(v Value) string {
+	switch val := v.(type) {
+	case stringValue:
+		return val.value
+	case charValue:
+		return string(val.value)
+	case pairValue:
+		return val.format(displayString)
+	case hashMapValue:
+		return val.format(displayString)
+	case vectorValue:
+		return val.format(displayString)
+	}
+	return v.Str()
+}
+
+// Returns the contents of the string operands, after checking that they are
+// all strings.
+func stringArgs(operator string, operands []Atom) ([]string, error) {
+	if _, err := checkArgTypes(operator, &operands, []valueType{stringType}); err != nil {
+		return nil, err
+	}
+	strs := make([]string, 0, len(operands))
+	for _, o := range operands {
+		strs = append(strs, o.Val.(stringValue).value)
+	}
+	return strs, nil
+}
+
+// Returns the value of an operand which has to be an int.
+func intArg(operator string, operand Atom) (int64, error) {
+	operands := []Atom{operand}
+	if _, err := checkArgTypes(operator, &operands, []valueType{intType}); err != nil {
+		return 0, err
+	}
+	return operand.Val.(intValue).value, nil
+}
+
+// Returns the integer as a string in the given radix, which has to be between
+// 2 and 36.
+func integerToString(operator string, v Value, radix int64) (string, error) {
+	if radix < 2 || radix > 36 {
+		return "", newTypeError(operator, nil, "Radix has to be between 2 and 36, got %d.", radix)
+	}
+	n, err := v.to(bigIntType)
+	if err != nil || (v.getValueType() != intType && v.getValueType() != bigIntType) {
+		return "", newTypeError(operator, []Value{v},
+			"Only integers can be written in radix %d, got %s.", radix, v.Str())
+	}
+	return n.(bigIntValue).value.Text(int(radix)), nil
+}
+
+// This method builds the string for format. The directives in the control
+// string are replaced by the arguments, in order. ~a is replaced by the next
+// argument the way display prints it, and ~s the way write prints it. ~d
+// takes a number, and ~b, ~o and ~x take an integer, which is written in
+// binary, octal or hexadecimal. ~% is a line break, and ~~ is a tilde.
+func formatString(control string, args []Value) (string, error) {
+	var buffer bytes.Buffer
+	next := 0
+	nextArg := func(directive rune) (Value, error) {
+		if next >= len(args) {
+			return nil, newTypeError(format, nil, "Not enough arguments for ~%c in %q.", directive, control)
+		}
+		next++
+		return args[next-1], nil
+	}
+
+	runes := []rune(control)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '~' {
+			buffer.WriteRune(runes[i])
+			continue
+		}
+		i++
+		if i == len(runes) {
+			return "", newTypeError(format, nil, "Incomplete directive at the end of %q.", control)
+		}
+		directive := runes[i]
+		switch directive {
+		case '%':
+			buffer.WriteString("\n")
+		case '~':
+			buffer.WriteString("~")
+		case 'a', 's', 'd', 'b', 'o', 'x':
+			arg, err := nextArg(directive)
+			if err != nil {
+				return "", err
+			}
+			switch directive {
+			case 'a':
+				buffer.WriteString(displayString(arg))
+			case 's':
+				buffer.WriteString(arg.Str())
+			case 'd':
+				if !isNumber(arg) {
+					return "", newTypeError(format, []Value{arg}, "~d expects a number, got %s.", arg.Str())
+				}
+				buffer.WriteString(arg.Str())
+			default:
+				radix := map[rune]int64{'b': 2, 'o': 8, 'x': 16}[directive]
+				str, err := integerToString(format, arg, radix)
+				if err != nil {
+					return "", err
+				}
+				buffer.WriteString(str)
+			}
+		default:
+			return "", newTypeError(format, nil, "Unknown directive ~%c in %q.", directive, control)
+		}
+	}
+	if next < len(args) {
+		return "", newTypeError(format, args[next:], "Too many arguments for %q.", control)
+	}
+	return buffer.String(), nil
+}
+
+func addStringOperators(opMap map[string]*Operator) {
+	addOperator(opMap,
+		&Operator{
+			symbol:      stringLength,
+			minArgCount: 1,
+			maxArgCount: 1,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				strs, err := stringArgs(stringLength, operands)
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				var val intValue
+				val.value = int64(utf8.RuneCountInString(strs[0]))
+				retVal.Val = val
+				return retVal
+			},
+		},
+	)
+
+	// (substring s start end) is the part of s from the character at start,
+	// up to the one at end. end is the end of s, if it is left out.
+	addOperator(opMap,
+		&Operator{
+			symbol:      substring,
+			minArgCount: 2,
+			maxArgCount: 3,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				strs, err := stringArgs(substring, operands[:1])
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				runes := []rune(strs[0])
+				bounds := []int64{0, int64(len(runes))}
+				for i, o := range operands[1:] {
+					if bounds[i], err = intArg(substring, o); err != nil {
+						retVal.Err = err
+						return retVal
+					}
+				}
+				if bounds[0] < 0 || bounds[0] > bounds[1] || bounds[1] > int64(len(runes)) {
+					retVal.Err = newEvalError("Cannot take the characters from %d to %d of %s, which has %d.",
+						bounds[0], bounds[1], operands[0].Val.Str(), len(runes))
+					return retVal
+				}
+				retVal.Val = newStringValue(string(runes[bounds[0]:bounds[1]]))
+				return retVal
+			},
+		},
+	)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      stringAppend,
+			minArgCount: 0,
+			maxArgCount: math.MaxInt32,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				strs, err := stringArgs(stringAppend, operands)
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				retVal.Val = newStringValue(strings.Join(strs, ""))
+				return retVal
+			},
+		},
+	)
+
+	conversions := map[string]func(string) string{
+		stringUpcase:   upcaseString,
+		stringDowncase: strings.ToLower,
+		stringTrim:     strings.TrimSpace,
+	}
+	for _, symbol := range []string{stringUpcase, stringDowncase, stringTrim} {
+		convSymbol := symbol
+		convert := conversions[convSymbol]
+		addOperator(opMap,
+			&Operator{
+				symbol:      convSymbol,
+				minArgCount: 1,
+				maxArgCount: 1,
+				handler: func(env *LangEnv, operands []Atom) Atom {
+					var retVal Atom
+					strs, err := stringArgs(convSymbol, operands)
+					if err != nil {
+						retVal.Err = err
+						return retVal
+					}
+					retVal.Val = newStringValue(convert(strs[0]))
+					return retVal
+				},
+			},
+		)
+	}
+
+	// (string-split s sep) is the list of the parts of s between the
+	// occurrences of sep. Without sep, s is split at whitespace.
+	addOperator(opMap,
+		&Operator{
+			symbol:      stringSplit,
+			minArgCount: 1,
+			maxArgCount: 2,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				strs, err := stringArgs(stringSplit, operands)
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				var parts []string
+				if len(strs) == 1 {
+					parts = strings.Fields(strs[0])
+				} else {
+					parts = strings.Split(strs[0], strs[1])
+				}
+				values := make([]Value, 0, len(parts))
+				for _, part := range parts {
+					values = append(values, newStringValue(part))
+				}
+				retVal.Val = newList(values)
+				return retVal
+			},
+		},
+	)
+
+	// (string-join strs sep) joins the list of strings, with sep between
+	// them. sep is a space, if it is left out.
+	addOperator(opMap,
+		&Operator{
+			symbol:      stringJoin,
+			minArgCount: 1,
+			maxArgCount: 2,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				values, err := listToSlice(operands[0].Val)
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				parts := make([]Atom, 0, len(values))
+				for _, v := range values {
+					var o Atom
+					o.Val = v
+					parts = append(parts, o)
+				}
+				strs, err := stringArgs(stringJoin, append(parts, operands[1:]...))
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				sep := " "
+				if len(operands) == 2 {
+					sep = strs[len(strs)-1]
+					strs = strs[:len(strs)-1]
+				}
+				retVal.Val = newStringValue(strings.Join(strs, sep))
+				return retVal
+			},
+		},
+	)
+
+	// (string-index s sub) is the position of the first occurrence of sub in
+	// s, counted in characters, or false if there is none.
+	addOperator(opMap,
+		&Operator{
+			symbol:      stringIndex,
+			minArgCount: 2,
+			maxArgCount: 2,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				strs, err := stringArgs(stringIndex, operands)
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				i := strings.Index(strs[0], strs[1])
+				if i < 0 {
+					retVal.Val = newBoolValue(false)
+					return retVal
+				}
+				var val intValue
+				val.value = int64(utf8.RuneCountInString(strs[0][:i]))
+				retVal.Val = val
+				return retVal
+			},
+		},
+	)
+
+	// (string-replace s old new) replaces every occurrence of old in s.
+	addOperator(opMap,
+		&Operator{
+			symbol:      stringReplace,
+			minArgCount: 3,
+			maxArgCount: 3,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				strs, err := stringArgs(stringReplace, operands)
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				retVal.Val = newStringValue(strings.Replace(strs[0], strs[1], strs[2], -1))
+				return retVal
+			},
+		},
+	)
+
+	// (string->number s radix) is the number s is written as, or false if it
+	// is not a number. Only integers can have a radix other than 10.
+	addOperator(opMap,
+		&Operator{
+			symbol:      stringToNumber,
+			minArgCount: 1,
+			maxArgCount: 2,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				strs, err := stringArgs(stringToNumber, operands[:1])
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				radix := int64(10)
+				if len(operands) == 2 {
+					if radix, err = intArg(stringToNumber, operands[1]); err != nil {
+						retVal.Err = err
+						return retVal
+					}
+				}
+				retVal.Val = newBoolValue(false)
+				if radix != 10 {
+					if radix < 2 || radix > 36 {
+						retVal.Err = newTypeError(stringToNumber, nil, "Radix has to be between 2 and 36, got %d.", radix)
+					} else if n, ok := new(big.Int).SetString(strs[0], int(radix)); ok {
+						retVal.Val = newBigIntValue(n)
+					}
+					return retVal
+				}
+				// The string is read like a token of the source would be.
+				for _, t := range env.types {
+					if t.ofType(strs[0]) {
+						if v := t.newValue(strs[0]); v != nil && isNumber(v) {
+							retVal.Val = v
+						}
+						break
+					}
+				}
+				return retVal
+			},
+		},
+	)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      numberToString,
+			minArgCount: 1,
+			maxArgCount: 2,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				if !isNumber(operands[0].Val) {
+					retVal.Err = newTypeError(numberToString, []Value{operands[0].Val},
+						"%s expects a number, got %s.", numberToString, operands[0].Val.Str())
+					return retVal
+				}
+				if len(operands) == 1 {
+					retVal.Val = newStringValue(operands[0].Val.Str())
+					return retVal
+				}
+				radix, err := intArg(numberToString, operands[1])
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				str, err := integerToString(numberToString, operands[0].Val, radix)
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				retVal.Val = newStringValue(str)
+				return retVal
+			},
+		},
+	)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      format,
+			minArgCount: 1,
+			maxArgCount: math.MaxInt32,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				control, err := stringArgs(format, operands[:1])
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				args := make([]Value, 0, len(operands)-1)
+				for _, o := range operands[1:] {
+					args = append(args, o.Val)
+				}
+				str, err := formatString(control[0], args)
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				retVal.Val = newStringValue(str)
+				return retVal
+			},
+		},
+	)
+
+	// display prints values for people to read, so strings are printed
+	// without quotes, whereas write prints them the way they would be read
+	// back. Neither adds a line break, which is what newline is for.
+	printers := map[string]func(Value) string{
+		display: displayString,
+		write:   Value.Str,
+	}
+	for _, symbol := range []string{display, write} {
+		printSymbol := symbol
+		printer := printers[printSymbol]
+		addOperator(opMap,
+			&Operator{
+				symbol:      printSymbol,
+				minArgCount: 1,
+				maxArgCount: 1,
+				handler: func(env *LangEnv, operands []Atom) Atom {
+					var retVal Atom
+					fmt.Fprint(env.global().output, printer(operands[0].Val))
+					retVal.Val = voidValue{}
+					return retVal
+				},
+			},
+		)
+	}
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      newline,
+			minArgCount: 0,
+			maxArgCount: 0,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				fmt.Fprintln(env.global().output)
+				retVal.Val = voidValue{}
+				return retVal
+			},
+		},
+	)
+}
@@ -0,0 +1,433 @@
+package lang
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// Syntax operators
+	defineSyntax string = "define-syntax"
+	syntaxRules  string = "syntax-rules"
+
+	// Keywords in syntax-rules patterns
+	ellipsis   string = "..."
+	underscore string = "_"
+)
+
+// A syntaxRule is a single (pattern template) pair of a syntax-rules macro.
+type syntaxRule struct {
+	pattern  Value
+	template Value
+}
+
+// A syntaxRulesMacro expands a form with the template of the first rule whose
+// pattern matches it. The names which the template introduces are renamed in
+// every expansion, and refer to the names in env, which is where the macro was
+// defined. This way, bindings made by the template never capture the names in
+// the operands, and bindings around the call never change what the names in
+// the template refer to.
+type syntaxRulesMacro struct {
+	name     string
+	literals map[string]bool
+	rules    []syntaxRule
+	env      *LangEnv
+}
+
+// A syntaxBinding is what a pattern variable matched. A variable which is
+// followed by an ellipsis matches a sequence, with one item per repetition.
+type syntaxBinding struct {
+	form     Value
+	ellipsis bool
+	items    []*syntaxBinding
+}
+
+// Splits a list into its elements, and its last cdr, which is the empty list
+// for a proper list.
+func splitList(list Value) ([]Value, Value) {
+	values := make([]Value, 0)
+	for {
+		pair, ok := list.(pairValue)
+		if !ok {
+			return values, list
+		}
+		values = append(values, pair.car)
+		list = pair.cdr
+	}
+}
+
+func isEllipsis(v Value) bool {
+	sym, ok := v.(symbolValue)
+	return ok && sym.value == ellipsis
+}
+
+// Returns the value with the renamed names in it replaced by the original
+// ones, so that quoting code from a template gives the names in the template.
+func syntaxToDatum(env *LangEnv, v Value) Value {
+	switch val := v.(type) {
+	case symbolValue:
+		return newSymbolValue(env.baseName(val.value))
+	case pairValue:
+		return newPairValue(syntaxToDatum(env, val.car), syntaxToDatum(env, val.cdr))
+	}
+	return v
+}
+
+// This method reads (syntax-rules (literals ...) (pattern template) ...).
+func newSyntaxRulesMacro(env *LangEnv, name string, spec Value) (*syntaxRulesMacro, error) {
+	values, tail := splitList(spec)
+	if tail.getValueType() != emptyType || len(values) < 2 {
+		return nil, newSyntaxError(
+			"Expected %s to be of the format `(%s (literal ...) (pattern template) ...)`.",
+			name, syntaxRules)
+	}
+	keyword, ok := values[0].(symbolValue)
+	if !ok || env.baseName(keyword.value) != syntaxRules {
+		return nil, newSyntaxError("Expected %s after the name in %s, got %s.",
+			syntaxRules, defineSyntax, values[0].Str())
+	}
+
+	macro := new(syntaxRulesMacro)
+	macro.name = name
+	macro.env = env
+	macro.literals = make(map[string]bool)
+	literals, tail := splitList(values[1])
+	if tail.getValueType() != emptyType {
+		return nil, newSyntaxError("Expected a list of literals in %s, got %s.", syntaxRules, values[1].Str())
+	}
+	for _, l := range literals {
+		sym, ok := l.(symbolValue)
+		if !ok || sym.value == ellipsis || sym.value == underscore {
+			return nil, newSyntaxError("Malformed literal %s in %s.", l.Str(), syntaxRules)
+		}
+		macro.literals[sym.value] = true
+	}
+
+	for _, r := range values[2:] {
+		parts, tail := splitList(r)
+		if tail.getValueType() != emptyType || len(parts) != 2 || parts[0].getValueType() != pairType {
+			return nil, newSyntaxError("Rules in %s should be of the format `(pattern template)`, got %s.",
+				syntaxRules, r.Str())
+		}
+		// The first element of the pattern stands for the name of the macro,
+		// and is never matched.
+		pattern := parts[0].(pairValue).cdr
+		if err := macro.checkPattern(pattern); err != nil {
+			return nil, err
+		}
+		macro.rules = append(macro.rules, syntaxRule{pattern, parts[1]})
+	}
+	return macro, nil
+}
+
+// Checks that every list in the pattern has at most one ellipsis, and that it
+// comes after the pattern it repeats.
+func (m *syntaxRulesMacro) checkPattern(pattern Value) error {
+	if _, ok := pattern.(pairValue); !ok {
+		return nil
+	}
+	patterns, tail := splitList(pattern)
+	found := false
+	for i, p := range patterns {
+		if !isEllipsis(p) {
+			if err := m.checkPattern(p); err != nil {
+				return err
+			}
+			continue
+		}
+		if found || i == 0 {
+			return newSyntaxError("Misplaced %s in the pattern %s of %s.", ellipsis, pattern.Str(), m.name)
+		}
+		found = true
+	}
+	return m.checkPattern(tail)
+}
+
+// Returns the pattern variables in a pattern.
+func (m *syntaxRulesMacro) patternVars(pattern Value) []string {
+	switch p := pattern.(type) {
+	case symbolValue:
+		if p.value == ellipsis || p.value == underscore || m.literals[p.value] {
+			return nil
+		}
+		return []string{p.value}
+	case pairValue:
+		return append(m.patternVars(p.car), m.patternVars(p.cdr)...)
+	}
+	return nil
+}
+
+// This method matches a form against a pattern, and records what the pattern
+// variables matched in bindings. It returns false if the form does not match.
+func (m *syntaxRulesMacro) match(env *LangEnv, pattern, form Value, bindings map[string]*syntaxBinding) bool {
+	switch p := pattern.(type) {
+	case symbolValue:
+		if p.value == underscore {
+			return true
+		}
+		if m.literals[p.value] {
+			sym, ok := form.(symbolValue)
+			return ok && env.baseName(sym.value) == p.value
+		}
+		bindings[p.value] = &syntaxBinding{form: form}
+		return true
+
+	case pairValue:
+		patterns, patternTail := splitList(p)
+		forms, formTail := splitList(form)
+		repeated := -1
+		for i, sub := range patterns {
+			if isEllipsis(sub) {
+				repeated = i - 1
+			}
+		}
+
+		if repeated == -1 {
+			if len(forms) < len(patterns) {
+				return false
+			}
+			for i, sub := range patterns {
+				if !m.match(env, sub, forms[i], bindings) {
+					return false
+				}
+			}
+			// Whatever is left over has to match the tail of the pattern.
+			rest := formTail
+			for i := len(forms) - 1; i >= len(patterns); i-- {
+				rest = newPairValue(forms[i], rest)
+			}
+			return m.match(env, patternTail, rest, bindings)
+		}
+
+		before := patterns[:repeated]
+		after := patterns[repeated+2:]
+		count := len(forms) - len(before) - len(after)
+		if count < 0 {
+			return false
+		}
+		for i, sub := range before {
+			if !m.match(env, sub, forms[i], bindings) {
+				return false
+			}
+		}
+		vars := m.patternVars(patterns[repeated])
+		for _, v := range vars {
+			bindings[v] = &syntaxBinding{ellipsis: true, items: make([]*syntaxBinding, 0)}
+		}
+		for _, f := range forms[len(before) : len(before)+count] {
+			itemBindings := make(map[string]*syntaxBinding)
+			if !m.match(env, patterns[repeated], f, itemBindings) {
+				return false
+			}
+			for _, v := range vars {
+				bindings[v].items = append(bindings[v].items, itemBindings[v])
+			}
+		}
+		for i, sub := range after {
+			if !m.match(env, sub, forms[len(before)+count+i], bindings) {
+				return false
+			}
+		}
+		return m.match(env, patternTail, formTail, bindings)
+
+	case emptyListValue:
+		return form.getValueType() == emptyType
+	}
+	return pattern.getValueType() == form.getValueType() && pattern.Str() == form.Str()
+}
+
+// Returns the name a name introduced by the template is renamed to in this
+// expansion.
+func (m *syntaxRulesMacro) rename(name string, renames map[string]string) Value {
+	alias, ok := renames[name]
+	if !ok {
+		alias = freshName(m.env, name)
+		renames[name] = alias
+	}
+	return newSymbolValue(alias)
+}
+
+// This method fills in a template with what the pattern variables matched.
+// An element followed by an ellipsis is repeated once for every item of the
+// variables in it. In an escaped template, like the x in (... x), ellipses are
+// just names.
+func (m *syntaxRulesMacro) expand(template Value, bindings map[string]*syntaxBinding,
+	renames map[string]string, escaped bool) (Value, error) {
+	switch t := template.(type) {
+	case symbolValue:
+		if binding, ok := bindings[t.value]; ok {
+			if binding.ellipsis {
+				return nil, newSyntaxError("The pattern variable %s is used without %s in %s.",
+					t.value, ellipsis, m.name)
+			}
+			return binding.form, nil
+		}
+		if t.value == ellipsis {
+			if !escaped {
+				return nil, newSyntaxError("Misplaced %s in the template of %s.", ellipsis, m.name)
+			}
+			return t, nil
+		}
+		return m.rename(t.value, renames), nil
+
+	case pairValue:
+		templates, tail := splitList(t)
+		if !escaped && len(templates) == 2 && isEllipsis(templates[0]) && tail.getValueType() == emptyType {
+			return m.expand(templates[1], bindings, renames, true)
+		}
+
+		values := make([]Value, 0, len(templates))
+		for i := 0; i < len(templates); i++ {
+			depth := 0
+			for !escaped && i+depth+1 < len(templates) && isEllipsis(templates[i+depth+1]) {
+				depth++
+			}
+			expanded, err := m.expandRepeated(templates[i], depth, bindings, renames, escaped)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, expanded...)
+			i += depth
+		}
+		result, err := m.expand(tail, bindings, renames, escaped)
+		if err != nil {
+			return nil, err
+		}
+		for i := len(values) - 1; i >= 0; i-- {
+			result = newPairValue(values[i], result)
+		}
+		return result, nil
+	}
+	return template, nil
+}
+
+// Expands a template which is followed by depth ellipses.
+func (m *syntaxRulesMacro) expandRepeated(template Value, depth int, bindings map[string]*syntaxBinding,
+	renames map[string]string, escaped bool) ([]Value, error) {
+	if depth == 0 {
+		expanded, err := m.expand(template, bindings, renames, escaped)
+		if err != nil {
+			return nil, err
+		}
+		return []Value{expanded}, nil
+	}
+
+	vars := make([]string, 0)
+	count := -1
+	for _, v := range m.patternVars(template) {
+		binding, ok := bindings[v]
+		if !ok || !binding.ellipsis {
+			continue
+		}
+		if count != -1 && len(binding.items) != count {
+			return nil, newSyntaxError("The pattern variables in %s matched a different number of forms in %s.",
+				template.Str(), m.name)
+		}
+		count = len(binding.items)
+		vars = append(vars, v)
+	}
+	if len(vars) == 0 {
+		return nil, newSyntaxError("There are no pattern variables to repeat in %s in %s.", template.Str(), m.name)
+	}
+
+	values := make([]Value, 0, count)
+	for i := 0; i < count; i++ {
+		itemBindings := make(map[string]*syntaxBinding, len(bindings))
+		for k, v := range bindings {
+			itemBindings[k] = v
+		}
+		for _, v := range vars {
+			itemBindings[v] = bindings[v].items[i]
+		}
+		expanded, err := m.expandRepeated(template, depth-1, itemBindings, renames, escaped)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, expanded...)
+	}
+	return values, nil
+}
+
+// Expands the form with the first rule which matches it. The expansion has to
+// be evaluated in the frame which is returned along with it, as that is the
+// only place where the names it renamed refer to the names in the template.
+func (m *syntaxRulesMacro) expandForm(env *LangEnv, form Value) (Value, *LangEnv, error) {
+	operands := form.(pairValue).cdr
+	for _, rule := range m.rules {
+		bindings := make(map[string]*syntaxBinding)
+		if !m.match(env, rule.pattern, operands, bindings) {
+			continue
+		}
+		renames := make(map[string]string)
+		expanded, err := m.expand(rule.template, bindings, renames, false)
+		if err != nil {
+			return nil, env, err
+		}
+		aliases := make(map[string]syntaxAlias, len(renames))
+		for name, alias := range renames {
+			aliases[alias] = syntaxAlias{name, m.env}
+		}
+		return expanded, newExpansionEnv(env, aliases), nil
+	}
+	return nil, env, newSyntaxError("No rule of %s matches %s.", m.name, syntaxToDatum(env, form).Str())
+}
+
+func addSyntaxOperators(opMap map[string]*Operator) {
+	addOperator(opMap,
+		&Operator{
+			symbol:      defineSyntax,
+			minArgCount: 2,
+			maxArgCount: 2,
+			passRawAST:  true,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				astVal, _ := operands[0].Val.(astValue)
+				macroName, err := getVarName(env, astVal.astNodes[0], defineSyntax)
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				if env.getValue(macroName) != nil {
+					retVal.Err = newEvalError("Macro %s already defined as a variable", macroName)
+					return retVal
+				}
+				if env.getOperator(macroName) != nil {
+					retVal.Err = newEvalError("Macro %s already defined as an operator", macroName)
+					return retVal
+				}
+
+				spec, err := astToValue(env, astVal.astNodes[1])
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				macro, err := newSyntaxRulesMacro(env, macroName, spec)
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				addOperator(env.definitionFrame().opMap, newMacroOperator(macroName, 0, math.MaxInt32, macro.expandForm))
+
+				var val varValue
+				val.value = fmt.Sprintf("<Macro: %s>", macroName)
+				val.varName = macroName
+				retVal.Val = val
+				return retVal
+			},
+		},
+	)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      syntaxRules,
+			minArgCount: 1,
+			maxArgCount: math.MaxInt32,
+			passRawAST:  true,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				retVal.Err = newSyntaxError("%s can only be used inside %s.", syntaxRules, defineSyntax)
+				return retVal
+			},
+		},
+	)
+}
@@ -0,0 +1,165 @@
+package lang
+
+const (
+	// Type operators
+	typeOf         string = "type-of"
+	isNumberSymbol string = "number?"
+	isComplex      string = "complex?"
+	isReal         string = "real?"
+	isRational     string = "rational?"
+	isInteger      string = "integer?"
+	isExactInteger string = "exact-integer?"
+	isFloat        string = "float?"
+	isDecimal      string = "decimal?"
+	isExact        string = "exact?"
+	isInexact      string = "inexact?"
+	isString       string = "string?"
+	isChar         string = "char?"
+	isBoolean      string = "boolean?"
+	isSymbol       string = "symbol?"
+	isProcedure    string = "procedure?"
+	isHash         string = "hash?"
+	isVector       string = "vector?"
+)
+
+// The names type-of gives to the types of values. Integers are one type to
+// scripts, whether they fit in an int64 or not.
+var typeNames = map[valueType]string{
+	intType:     "integer",
+	bigIntType:  "integer",
+	ratType:     "rational",
+	decimalType: "decimal",
+	floatType:   "float",
+	complexType: "complex",
+	stringType:  "string",
+	charType:    "char",
+	boolType:    "boolean",
+	symbolType:  "symbol",
+	pairType:    "pair",
+	emptyType:   "null",
+	lambdaType:  "procedure",
+	hashMapType: "hash",
+	vectorType:  "vector",
+	voidType:    "void",
+}
+
+// Returns the name of the type of the value, the way type-of gives it.
+// Operators are procedures, unless they are special forms, like if, which are
+// syntax.
+func typeName(env *LangEnv, v Value) string {
+	if varVal, ok := v.(varValue); ok {
+		if op := env.getOperator(varVal.varName); op != nil && !op.passRawAST {
+			return "procedure"
+		}
+		return "syntax"
+	}
+	if name, ok := typeNames[v.getValueType()]; ok {
+		return name
+	}
+	return v.getValueType().(string)
+}
+
+// Returns true if the value is a real number with no fractional part, like 2,
+// 2.0 or 2.00m.
+func isIntegral(v Value) bool {
+	switch val := v.(type) {
+	case intValue, bigIntValue:
+		return true
+	case floatValue:
+		return isWhole(val.value)
+	case decimalValue:
+		return val.reduce(0).scale <= 0
+	}
+	return false
+}
+
+func addTypeOperators(opMap map[string]*Operator) {
+	// (type-of x) is the type of x, as a symbol, like integer or string.
+	addOperator(opMap,
+		&Operator{
+			symbol:      typeOf,
+			minArgCount: 1,
+			maxArgCount: 1,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				var symbol symbolValue
+				retVal.Val = symbol.newValue(typeName(env, operands[0].Val))
+				return retVal
+			},
+		},
+	)
+
+	// Numbers can be floats and complex numbers, which are inexact, or any of
+	// the other types, which are exact.
+	predicates := map[string]func(*LangEnv, Value) bool{
+		isNumberSymbol: func(env *LangEnv, v Value) bool { return isNumber(v) },
+		isComplex:      func(env *LangEnv, v Value) bool { return isNumber(v) },
+		isReal: func(env *LangEnv, v Value) bool {
+			return isNumber(v) && v.getValueType() != complexType
+		},
+		isRational: func(env *LangEnv, v Value) bool {
+			if f, ok := v.(floatValue); ok {
+				return isFinite(f.value)
+			}
+			return isNumber(v) && v.getValueType() != complexType
+		},
+		isInteger: func(env *LangEnv, v Value) bool { return isIntegral(v) },
+		isExactInteger: func(env *LangEnv, v Value) bool {
+			return v.getValueType() == intType || v.getValueType() == bigIntType
+		},
+		isFloat:   func(env *LangEnv, v Value) bool { return v.getValueType() == floatType },
+		isDecimal: func(env *LangEnv, v Value) bool { return v.getValueType() == decimalType },
+		isString:  func(env *LangEnv, v Value) bool { return v.getValueType() == stringType },
+		isChar:    func(env *LangEnv, v Value) bool { return v.getValueType() == charType },
+		isBoolean: func(env *LangEnv, v Value) bool { return v.getValueType() == boolType },
+		isSymbol:  func(env *LangEnv, v Value) bool { return v.getValueType() == symbolType },
+		isProcedure: func(env *LangEnv, v Value) bool {
+			return typeName(env, v) == "procedure"
+		},
+		isHash:   func(env *LangEnv, v Value) bool { return v.getValueType() == hashMapType },
+		isVector: func(env *LangEnv, v Value) bool { return v.getValueType() == vectorType },
+	}
+	for _, symbol := range []string{isNumberSymbol, isComplex, isReal, isRational, isInteger,
+		isExactInteger, isFloat, isDecimal, isString, isChar, isBoolean, isSymbol, isProcedure,
+		isHash, isVector} {
+		predSymbol := symbol
+		holds := predicates[predSymbol]
+		addOperator(opMap,
+			&Operator{
+				symbol:      predSymbol,
+				minArgCount: 1,
+				maxArgCount: 1,
+				handler: func(env *LangEnv, operands []Atom) Atom {
+					var retVal Atom
+					retVal.Val = newBoolValue(holds(env, operands[0].Val))
+					return retVal
+				},
+			},
+		)
+	}
+
+	// exact? and inexact? only make sense for numbers, so anything else is an
+	// error.
+	numTypes := []valueType{intType, bigIntType, decimalType, ratType, floatType, complexType}
+	for _, symbol := range []string{isExact, isInexact} {
+		exactSymbol := symbol
+		addOperator(opMap,
+			&Operator{
+				symbol:      exactSymbol,
+				minArgCount: 1,
+				maxArgCount: 1,
+				handler: func(env *LangEnv, operands []Atom) Atom {
+					var retVal Atom
+					_, retVal.Err = checkArgTypes(exactSymbol, &operands, numTypes)
+					if retVal.Err != nil {
+						return retVal
+					}
+					valType := operands[0].Val.getValueType()
+					inexact := valType == floatType || valType == complexType
+					retVal.Val = newBoolValue(inexact == (exactSymbol == isInexact))
+					return retVal
+				},
+			},
+		)
+	}
+}
@@ -1,7 +1,6 @@
 package lang
 
 import (
-	"errors"
 	"fmt"
 )
 
@@ -17,17 +16,17 @@ func checkArgTypes(operatorName string, operands *[]Atom, allowedTypes []valueTy
 			}
 		}
 		if !exists {
-			return nil, errors.New(
-				fmt.Sprintf("For operator %s, operand %s is of unexpected type: %s.",
-					operatorName, operand.Val.Str(), operand.Val.getValueType()))
+			return nil, newTypeError(operatorName, []Value{operand.Val},
+				"For operator %s, operand %s is of unexpected type: %s.",
+				operatorName, operand.Val.Str(), operand.Val.getValueType())
 		}
 	}
 	return typesFound, nil
 }
 
-func pop(tokens []string) (string, []string) {
+func pop(tokens []token) (token, []token) {
 	if len(tokens) == 0 {
-		return "", tokens
+		return token{}, tokens
 	}
 	return tokens[0], tokens[1:]
 }
@@ -1,11 +1,13 @@
 package lang
 
 import (
-	"errors"
+	"bytes"
 	"fmt"
+	"math"
 	"math/big"
-	"regexp"
 	"strconv"
+	"strings"
+	"unicode"
 )
 
 // Different types of values supported
@@ -13,13 +15,25 @@ type valueType interface{}
 
 const (
 	// Value type
-	stringType = "stringType"
-	intType    = "intType"
-	bigIntType = "bigIntType"
-	floatType  = "floatType"
-	varType    = "varType"
-	boolType   = "boolType"
-	astType    = "astType"
+	stringType  = "stringType"
+	charType    = "charType"
+	intType     = "intType"
+	bigIntType  = "bigIntType"
+	ratType     = "ratType"
+	decimalType = "decimalType"
+	floatType   = "floatType"
+	complexType = "complexType"
+	varType     = "varType"
+	boolType    = "boolType"
+	astType     = "astType"
+	lambdaType  = "lambdaType"
+	tailType    = "tailType"
+	symbolType  = "symbolType"
+	pairType    = "pairType"
+	hashMapType = "hashMapType"
+	vectorType  = "vectorType"
+	emptyType   = "emptyListType"
+	voidType    = "voidType"
 )
 
 type Value interface {
@@ -30,22 +44,25 @@ type Value interface {
 	newValue(string) Value
 }
 
+// Characters, other than letters and digits, which can be used in names.
+const identifierChars = "!$%&*/:<=>?^_~+-.@"
+
 func getVarValue(env *LangEnv, varVal Value) (Value, error) {
 	if varVal != nil && varVal.getValueType() == varType {
 		varTypeVal, _ := varVal.(varValue)
 		varName := varTypeVal.varName
 
-		val := env.varMap[varName]
+		val := env.getValue(varName)
 		if val != nil {
 			return val, nil
 		}
-		opVal := env.opMap[varName]
+		opVal := env.getOperator(varName)
 		if opVal != nil {
 			return varVal, nil
 		}
-		return nil, errors.New(fmt.Sprintf("Undefined variable: %s", varName))
+		return nil, newUndefinedError(varName, false)
 	}
-	return nil, errors.New(fmt.Sprintf("Error while resolving variable."))
+	return nil, newEvalError("Error while resolving variable.")
 }
 
 // Algorithm
@@ -53,13 +70,12 @@ func getVarValue(env *LangEnv, varVal Value) (Value, error) {
 // 2. Pick the highest value type that complies.
 // 3. Return that value type.
 func getValue(env *LangEnv, token string) (Value, error) {
-	types := builtinTypes()
-	for _, t := range types {
+	for _, t := range env.types {
 		if t.ofType(token) {
 			return t.newValue(token), nil
 		}
 	}
-	return nil, errors.New(fmt.Sprintf("Could not get type for token: %s", token))
+	return nil, newSyntaxError("Could not get type for token: %s", token)
 }
 
 /*
@@ -76,9 +92,10 @@ NaN
 */
 
 func typeConvError(from, to valueType) error {
-	return errors.New(fmt.Sprintf("Cannot convert %s to %s", from, to))
+	return newTypeError("", nil, "Cannot convert %s to %s", from, to)
 }
 
+// A stringValue holds the contents of a string, without the quotes around it.
 type stringValue struct {
 	value string
 }
@@ -95,30 +112,51 @@ func (v stringValue) to(targetType valueType) (Value, error) {
 	return nil, typeConvError(v.getValueType(), targetType)
 }
 
+// Strings are printed the way they would be read back.
 func (v stringValue) Str() string {
-	return v.value
+	return strconv.Quote(v.value)
 }
 
+// A string literal is enclosed in double quotes, and can contain escape
+// sequences like \n, \" and \u00e9.
 func (v stringValue) ofType(targetValue string) bool {
-	valLen := len(targetValue)
-	if valLen < 2 {
-		return false
-	}
-	// TODO
-	// Stricter checks for quotes inside strings, like ''' should not be valid.
-	f, l := targetValue[0], targetValue[valLen-1]
-	if (f == '\'' && l == '\'') || (f == '"' && l == '"') {
-		return true
-	}
-	return false
+	_, err := unquoteString(targetValue)
+	return err == nil
 }
 
 func (v stringValue) newValue(str string) Value {
+	contents, err := unquoteString(str)
+	if err != nil {
+		return nil
+	}
+	return newStringValue(contents)
+}
+
+func newStringValue(contents string) Value {
 	var val stringValue
-	val.value = str
+	val.value = contents
 	return val
 }
 
+// Returns the contents of a string literal, with the escape sequences
+// replaced by the characters they stand for.
+func unquoteString(literal string) (string, error) {
+	if len(literal) < 2 || literal[0] != '"' || literal[len(literal)-1] != '"' {
+		return "", newSyntaxError("%s is not a string literal", literal)
+	}
+	var buffer bytes.Buffer
+	rest := literal[1 : len(literal)-1]
+	for len(rest) > 0 {
+		r, _, tail, err := strconv.UnquoteChar(rest, '"')
+		if err != nil {
+			return "", newSyntaxError("Invalid string literal %s", literal)
+		}
+		buffer.WriteRune(r)
+		rest = tail
+	}
+	return buffer.String(), nil
+}
+
 type intValue struct {
 	value int64
 }
@@ -136,10 +174,18 @@ func (v intValue) to(targetType valueType) (Value, error) {
 		val.value = new(big.Int)
 		val.value.SetInt64(v.value)
 		return val, nil
+	case decimalType:
+		return newDecimalValue(big.NewInt(v.value), 0), nil
+	case ratType:
+		var val ratValue
+		val.value = new(big.Rat).SetInt64(v.value)
+		return val, nil
 	case floatType:
 		var val floatValue
 		val.value = float64(v.value)
 		return val, nil
+	case complexType:
+		return newComplexValue(complex(float64(v.value), 0)), nil
 	}
 	return nil, typeConvError(v.getValueType(), targetType)
 }
@@ -177,6 +223,8 @@ func (v bigIntValue) getValueType() valueType {
 
 func (v bigIntValue) to(targetType valueType) (Value, error) {
 	switch targetType {
+	case bigIntType:
+		return v, nil
 	case intType:
 		// Get the int64 representation, and
 		// try creating an big.Int out of it.
@@ -188,6 +236,18 @@ func (v bigIntValue) to(targetType valueType) (Value, error) {
 		}
 		// An alternate way would be to check if the bigInt is either smaller than
 		// the smallest value of int64, or larger than the largest value of int64.
+	case decimalType:
+		return newDecimalValue(new(big.Int).Set(v.value), 0), nil
+	case ratType:
+		var val ratValue
+		val.value = new(big.Rat).SetInt(v.value)
+		return val, nil
+	case floatType:
+		var val floatValue
+		val.value, _ = new(big.Float).SetInt(v.value).Float64()
+		return val, nil
+	case complexType:
+		return realToComplex(v)
 	}
 	return nil, typeConvError(v.getValueType(), targetType)
 }
@@ -218,10 +278,93 @@ func (v bigIntValue) newValue(str string) Value {
 	return val
 }
 
+// A ratValue is an exact fraction, like 3/4. Results which turn out to be
+// whole numbers are turned back into an intValue, or a bigIntValue.
+type ratValue struct {
+	value *big.Rat
+}
+
+func (v ratValue) getValueType() valueType {
+	return ratType
+}
+
+func (v ratValue) to(targetType valueType) (Value, error) {
+	switch targetType {
+	case ratType:
+		return v, nil
+	case floatType:
+		var val floatValue
+		val.value, _ = v.value.Float64()
+		return val, nil
+	case complexType:
+		return realToComplex(v)
+	}
+	return nil, typeConvError(v.getValueType(), targetType)
+}
+
+// A rational literal is two integers separated by a /, like 3/4 or -1/2.
+func (v ratValue) ofType(targetValue string) bool {
+	return v.newValue(targetValue) != nil
+}
+
+func (v ratValue) Str() string {
+	return v.value.RatString()
+}
+
+func (v ratValue) newValue(str string) Value {
+	parts := strings.Split(str, "/")
+	if len(parts) != 2 {
+		return nil
+	}
+	num, ok := new(big.Int).SetString(parts[0], 10)
+	if !ok {
+		return nil
+	}
+	// Only the numerator can have a sign.
+	if strings.HasPrefix(parts[1], "+") || strings.HasPrefix(parts[1], "-") {
+		return nil
+	}
+	denom, ok := new(big.Int).SetString(parts[1], 10)
+	if !ok || denom.Sign() == 0 {
+		return nil
+	}
+	return newRatValue(new(big.Rat).SetFrac(num, denom))
+}
+
+// Returns the integer as an intValue if it fits in one, and as a bigIntValue
+// otherwise.
+func newBigIntValue(n *big.Int) Value {
+	if n.IsInt64() {
+		var val intValue
+		val.value = n.Int64()
+		return val
+	}
+	var val bigIntValue
+	val.value = n
+	return val
+}
+
+// Returns the value of the fraction, which is an intValue or a bigIntValue if
+// it is a whole number.
+func newRatValue(r *big.Rat) Value {
+	if r.IsInt() {
+		return newBigIntValue(new(big.Int).Set(r.Num()))
+	}
+	var val ratValue
+	val.value = r
+	return val
+}
+
 type floatValue struct {
 	value float64
 }
 
+func newFloatValue(f float64) floatValue {
+	var val floatValue
+	val.value = f
+	return val
+}
+
 func (v floatValue) getValueType() valueType {
 	return floatType
 }
@@ -230,6 +373,24 @@ func (v floatValue) to(targetType valueType) (Value, error) {
 	switch targetType {
 	case floatType:
 		return v, nil
+	case intType, bigIntType:
+		// Only whole numbers can be converted to integers.
+		if math.IsInf(v.value, 0) || v.value != math.Trunc(v.value) {
+			break
+		}
+		n, _ := new(big.Float).SetFloat64(v.value).Int(nil)
+		if targetType == bigIntType {
+			var val bigIntValue
+			val.value = n
+			return val, nil
+		}
+		if n.IsInt64() {
+			var val intValue
+			val.value = n.Int64()
+			return val, nil
+		}
+	case complexType:
+		return newComplexValue(complex(v.value, 0)), nil
 	}
 	return nil, typeConvError(v.getValueType(), targetType)
 }
@@ -270,11 +431,52 @@ func (v varValue) to(targetType valueType) (Value, error) {
 	return nil, typeConvError(v.getValueType(), targetType)
 }
 
+// Names made by gensym and syntax-rules end in a count in braces, like g{1}.
+// Braces end a name in the reader, so these names can never clash with a name
+// written in the source.
+func freshName(env *LangEnv, prefix string) string {
+	global := env.global()
+	global.gensymCount++
+	return fmt.Sprintf("%s{%d}", prefix, global.gensymCount)
+}
+
+// Returns the name without the counts freshName added to it.
+func stripFreshName(name string) string {
+	for strings.HasSuffix(name, "}") {
+		i := strings.LastIndex(name, "{")
+		if i <= 0 || i+2 > len(name)-1 || !isDigits(name[i+1:len(name)-1]) {
+			break
+		}
+		name = name[:i]
+	}
+	return name
+}
+
+func isFreshName(name string) bool {
+	return stripFreshName(name) != name
+}
+
+// Variable names follow the Lisp tradition, and can contain characters like
+// -, >, ?, ! and *. So list->vector, null?, set! and *global* are all valid
+// names, as are + and -.
 func (v varValue) ofType(targetValue string) bool {
-	matched, err := regexp.MatchString("[a-zA-Z]+[a-zA-Z0-9]*", targetValue)
-	if matched == false || err != nil {
+	targetValue = stripFreshName(targetValue)
+	if len(targetValue) == 0 || targetValue == dot {
 		return false
 	}
+	for i, r := range targetValue {
+		if unicode.IsLetter(r) || strings.ContainsRune(identifierChars, r) {
+			continue
+		}
+		if unicode.IsDigit(r) && i > 0 {
+			continue
+		}
+		return false
+	}
+	// Things like -1 and .5 are numbers, not names.
+	if len(targetValue) > 1 && strings.ContainsRune("+-.", rune(targetValue[0])) {
+		return !unicode.IsDigit(rune(targetValue[1]))
+	}
 	return true
 }
 
@@ -332,6 +534,13 @@ func newBoolValue(b bool) Value {
 	return val
 }
 
+// Returns false only for the boolean false. Every other value, including 0,
+// "" and the empty list, counts as true in conditions.
+func isTruthy(v Value) bool {
+	b, ok := v.(boolValue)
+	return !ok || b.value
+}
+
 type astValue struct {
 	astNodes      []*ASTNode
 	parentASTNode *ASTNode
@@ -364,8 +573,247 @@ func newASTValue(parentNode *ASTNode) Value {
 	return val
 }
 
-type method struct {
-	methodName string
-	params     []string
-	ast        *ASTNode
+// A symbolValue is a name used as data, like the result of (quote foo). Unlike
+// a varValue, it is never resolved to the value of a variable.
+type symbolValue struct {
+	value string
+}
+
+func (v symbolValue) getValueType() valueType {
+	return symbolType
+}
+
+func (v symbolValue) to(targetType valueType) (Value, error) {
+	return nil, typeConvError(v.getValueType(), targetType)
+}
+
+func (v symbolValue) ofType(targetValue string) bool {
+	return false
+}
+
+func (v symbolValue) Str() string {
+	return v.value
+}
+
+func (v symbolValue) newValue(str string) Value {
+	var val symbolValue
+	val.value = str
+	return val
+}
+
+func newSymbolValue(name string) Value {
+	var val symbolValue
+	val.value = name
+	return val
+}
+
+// A pairValue is a cons cell. Lists are chains of pairs, where the cdr of the
+// last pair is the empty list.
+type pairValue struct {
+	car Value
+	cdr Value
+}
+
+func (v pairValue) getValueType() valueType {
+	return pairType
+}
+
+func (v pairValue) to(targetType valueType) (Value, error) {
+	switch targetType {
+	case vectorType:
+		elems, err := listToSlice(v)
+		if err != nil {
+			return nil, err
+		}
+		return newVectorValue(elems), nil
+	}
+	return nil, typeConvError(v.getValueType(), targetType)
+}
+
+func (v pairValue) ofType(targetValue string) bool {
+	return false
+}
+
+func (v pairValue) Str() string {
+	return v.format(Value.Str)
+}
+
+// Returns the list in brackets, with each of its elements formatted by
+// elemStr.
+func (v pairValue) format(elemStr func(Value) string) string {
+	var buffer bytes.Buffer
+	buffer.WriteString("(")
+	buffer.WriteString(elemStr(v.car))
+	rest := v.cdr
+	for {
+		if pair, ok := rest.(pairValue); ok {
+			buffer.WriteString(" ")
+			buffer.WriteString(elemStr(pair.car))
+			rest = pair.cdr
+			continue
+		}
+		if rest.getValueType() != emptyType {
+			buffer.WriteString(" . ")
+			buffer.WriteString(elemStr(rest))
+		}
+		break
+	}
+	buffer.WriteString(")")
+	return buffer.String()
+}
+
+func (v pairValue) newValue(str string) Value {
+	return nil
+}
+
+func newPairValue(head, tail Value) Value {
+	var val pairValue
+	val.car = head
+	val.cdr = tail
+	return val
+}
+
+type emptyListValue struct{}
+
+func (v emptyListValue) getValueType() valueType {
+	return emptyType
+}
+
+func (v emptyListValue) to(targetType valueType) (Value, error) {
+	switch targetType {
+	case vectorType:
+		return newVectorValue(make([]Value, 0)), nil
+	}
+	return nil, typeConvError(v.getValueType(), targetType)
+}
+
+func (v emptyListValue) ofType(targetValue string) bool {
+	return false
+}
+
+func (v emptyListValue) Str() string {
+	return "()"
+}
+
+func (v emptyListValue) newValue(str string) Value {
+	return nil
+}
+
+// A voidValue is what operators which are only called for what they do, like
+// display, return. It is not printed when it is the value of an expression
+// evaluated at the top level.
+type voidValue struct{}
+
+func (v voidValue) getValueType() valueType {
+	return voidType
+}
+
+func (v voidValue) to(targetType valueType) (Value, error) {
+	return nil, typeConvError(v.getValueType(), targetType)
+}
+
+func (v voidValue) ofType(targetValue string) bool {
+	return false
+}
+
+func (v voidValue) Str() string {
+	return "#<void>"
+}
+
+func (v voidValue) newValue(str string) Value {
+	return nil
+}
+
+// A tailCallValue is returned by operators instead of a value, when the value
+// is that of an expression in tail position. evalAST evaluates it without
+// growing the Go stack.
+type tailCallValue struct {
+	env  *LangEnv
+	node *ASTNode
+}
+
+func (v tailCallValue) getValueType() valueType {
+	return tailType
+}
+
+func (v tailCallValue) to(targetType valueType) (Value, error) {
+	return nil, typeConvError(v.getValueType(), targetType)
+}
+
+func (v tailCallValue) ofType(targetValue string) bool {
+	return false
+}
+
+func (v tailCallValue) Str() string {
+	return StringifyAST(v.node)
+}
+
+func (v tailCallValue) newValue(str string) Value {
+	return nil
+}
+
+func newTailCall(env *LangEnv, node *ASTNode) Atom {
+	var retVal Atom
+	var val tailCallValue
+	val.env = env
+	val.node = node
+	retVal.Val = val
+	return retVal
+}
+
+// A lambdaValue is an anonymous method. It remembers the environment it was
+// defined in, so that it can be called from anywhere.
+// If rest is set, the lambda takes any number of arguments after params, and
+// they are bound to rest as a list.
+// A lambdaValue is a procedure, along with the environment it was made in.
+// Every lambda expression evaluated makes a new procedure, with an id of its
+// own, so two procedures are only the same if they are copies of each other,
+// however alike they look. Procedures made with (define (name ...) ...) have
+// the name they were defined with.
+type lambdaValue struct {
+	id     int
+	name   string
+	params []string
+	rest   string
+	body   []*ASTNode
+	env    *LangEnv
+}
+
+func (v lambdaValue) getValueType() valueType {
+	return lambdaType
+}
+
+func (v lambdaValue) to(targetType valueType) (Value, error) {
+	return nil, typeConvError(v.getValueType(), targetType)
+}
+
+func (v lambdaValue) ofType(targetValue string) bool {
+	return false
+}
+
+func (v lambdaValue) Str() string {
+	params := strings.Join(v.params, " ")
+	if len(v.rest) > 0 {
+		params = strings.TrimSpace(params + " " + dot + " " + v.rest)
+	}
+	if len(v.name) > 0 {
+		return fmt.Sprintf("<Lambda: %s (%s)>", v.name, params)
+	}
+	return fmt.Sprintf("<Lambda: (%s)>", params)
+}
+
+func (v lambdaValue) newValue(str string) Value {
+	return nil
+}
+
+func newLambdaValue(env *LangEnv, params []string, rest string, body []*ASTNode) lambdaValue {
+	var val lambdaValue
+	val.params = params
+	val.rest = rest
+	val.body = body
+	val.env = env
+	global := env.global()
+	global.lambdaCount++
+	val.id = global.lambdaCount
+	return val
 }
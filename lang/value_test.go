@@ -2,6 +2,7 @@ package lang
 
 import (
 	"fmt"
+	"math/big"
 	"testing"
 )
 
@@ -92,20 +93,50 @@ func TestfloatValue(t *testing.T) {
 	doChecks(fv, strCases, t)
 }
 
+func TestNumberConversions(t *testing.T) {
+	var fv floatValue
+	fv.value = 3
+	conv, err := fv.to(intType)
+	if err != nil || conv.getValueType() != intType || conv.Str() != "3" {
+		t.Errorf("Could not convert from floatType to intType")
+	}
+
+	fv.value = 1e20
+	conv, err = fv.to(bigIntType)
+	if err != nil || conv.getValueType() != bigIntType || conv.Str() != "100000000000000000000" {
+		t.Errorf("Could not convert from floatType to bigIntType")
+	}
+	if _, err = fv.to(intType); err == nil {
+		t.Errorf("Converted %s to intType, which is too small for it", fv.Str())
+	}
+
+	fv.value = 1.5
+	if _, err = fv.to(intType); err == nil {
+		t.Errorf("Converted %s to intType, which can only hold whole numbers", fv.Str())
+	}
+
+	var bv bigIntValue
+	bv.value = new(big.Int).Lsh(big.NewInt(1), 70)
+	conv, err = bv.to(floatType)
+	if err != nil || conv.getValueType() != floatType || conv.Str() != "1.1805916207174113e+21" {
+		t.Errorf("Could not convert from bigIntType to floatType")
+	}
+}
+
 func TeststringValue(t *testing.T) {
 	sv := new(stringValue)
 	cases := make([]TestPair, 0)
 	cases = append(cases, TestPair{"", false})
-	cases = append(cases, TestPair{"''", true})
-	cases = append(cases, TestPair{"'abc'", true})
+	cases = append(cases, TestPair{"''", false})
+	cases = append(cases, TestPair{"'abc'", false})
 	cases = append(cases, TestPair{"\"\"", true})
 	cases = append(cases, TestPair{"\"abc\"", true})
 	cases = append(cases, TestPair{"1.2", false})
 	doTypeChecks(sv, cases, t)
 
 	strCases := make([]TestPair, 0)
-	sv.value = "\"abc\""
-	strCases = append(strCases, TestPair{sv.Str(), sv.value})
+	sv.value = "abc"
+	strCases = append(strCases, TestPair{sv.Str(), "\"abc\""})
 
 	doChecks(sv, strCases, t)
 }
@@ -123,7 +154,27 @@ func TestTypeInfer(t *testing.T) {
 		t.Errorf("Could not correctly getValue(1)")
 	}
 
-	v, e = getValue(env, "'xyz'")
+	v, e = getValue(env, "1/2")
+	if v == nil || v.getValueType() != ratType || e != nil {
+		t.Errorf("Could not correctly getValue(1/2)")
+	}
+
+	v, e = getValue(env, "1.50m")
+	if v == nil || v.getValueType() != decimalType || e != nil || v.Str() != "1.50m" {
+		t.Errorf("Could not correctly getValue(1.50m)")
+	}
+
+	v, e = getValue(env, "3+4i")
+	if v == nil || v.getValueType() != complexType || e != nil || v.Str() != "3+4i" {
+		t.Errorf("Could not correctly getValue(3+4i)")
+	}
+
+	v, e = getValue(env, "#\\x3bb")
+	if v == nil || v.getValueType() != charType || e != nil || v.Str() != "#\\λ" {
+		t.Errorf("Could not correctly getValue(#\\x3bb)")
+	}
+
+	v, e = getValue(env, "\"xyz\"")
 	if v == nil || v.getValueType() != stringType || e != nil {
 		t.Errorf("Could not correctly getValue(1)")
 	}
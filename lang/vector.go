@@ -0,0 +1,341 @@
+package lang
+
+import (
+	"math"
+	"strings"
+)
+
+const (
+	// Vector operators
+	vector       string = "vector"
+	makeVector   string = "make-vector"
+	vectorRef    string = "vector-ref"
+	vectorSet    string = "vector-set!"
+	vectorLength string = "vector-length"
+	vectorFill   string = "vector-fill!"
+	vectorSlice  string = "vector-slice"
+	vectorToList string = "vector->list"
+	listToVector string = "list->vector"
+)
+
+// The largest vector make-vector will make.
+const maxVectorLength int64 = 1 << 24
+
+// A vectorValue is a fixed-length sequence of values, which can be read and
+// changed at any position in constant time. Copies of a vectorValue share the
+// same elements, so a change through vector-set! is seen by everyone holding
+// the vector.
+type vectorValue struct {
+	elems []Value
+}
+
+func newVectorValue(elems []Value) vectorValue {
+	var val vectorValue
+	val.elems = elems
+	return val
+}
+
+func (v vectorValue) getValueType() valueType {
+	return vectorType
+}
+
+func (v vectorValue) to(targetType valueType) (Value, error) {
+	switch targetType {
+	case vectorType:
+		return v, nil
+	}
+	return nil, typeConvError(v.getValueType(), targetType)
+}
+
+// Vectors are built with #(...) or [...], which are read as (vector ...), so
+// there is no token for them.
+func (v vectorValue) ofType(targetValue string) bool {
+	return false
+}
+
+func (v vectorValue) Str() string {
+	return v.format(Value.Str)
+}
+
+// Returns the vector as #(...), with the elements printed by elemStr.
+func (v vectorValue) format(elemStr func(Value) string) string {
+	parts := make([]string, 0, len(v.elems))
+	for _, elem := range v.elems {
+		parts = append(parts, elemStr(elem))
+	}
+	return vectorPrefix + strings.Join(parts, " ") + closedBracket
+}
+
+func (v vectorValue) newValue(str string) Value {
+	return nil
+}
+
+// Returns the vector operand, after checking that it is one.
+func vectorArg(operator string, operand Atom) (vectorValue, error) {
+	operands := []Atom{operand}
+	if _, err := checkArgTypes(operator, &operands, []valueType{vectorType}); err != nil {
+		return vectorValue{}, err
+	}
+	return operand.Val.(vectorValue), nil
+}
+
+// Returns the index operand, after checking that it is a position in the
+// vector. end is true if the position just after the last element is allowed
+// too, as it is for the end of a slice.
+func vectorIndexArg(operator string, v vectorValue, operand Atom, end bool) (int64, error) {
+	i, err := intArg(operator, operand)
+	if err != nil {
+		return 0, err
+	}
+	last := int64(len(v.elems)) - 1
+	if end {
+		last++
+	}
+	if i < 0 || i > last {
+		return 0, newEvalError("Index %d is out of range for %s, which has %d elements.",
+			i, v.Str(), len(v.elems))
+	}
+	return i, nil
+}
+
+// Returns true if the value is the vector, or has it in it, anywhere in its
+// lists, vectors and hash maps. A vector can not be stored in itself, as it
+// could then not be printed.
+func containsVector(val Value, v vectorValue) bool {
+	switch elem := val.(type) {
+	case vectorValue:
+		if len(elem.elems) > 0 && len(v.elems) > 0 && &elem.elems[0] == &v.elems[0] {
+			return true
+		}
+		for _, e := range elem.elems {
+			if containsVector(e, v) {
+				return true
+			}
+		}
+	case pairValue:
+		return containsVector(elem.car, v) || containsVector(elem.cdr, v)
+	case hashMapValue:
+		for _, entry := range elem.entries {
+			if containsVector(entry.key, v) || containsVector(entry.value, v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func addVectorOperators(opMap map[string]*Operator) {
+	addOperator(opMap,
+		&Operator{
+			symbol:      vector,
+			minArgCount: 0,
+			maxArgCount: math.MaxInt32,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				elems := make([]Value, 0, len(operands))
+				for _, o := range operands {
+					elems = append(elems, o.Val)
+				}
+				retVal.Val = newVectorValue(elems)
+				return retVal
+			},
+		},
+	)
+
+	// (make-vector n fill) is a vector of n elements, which are all fill, or 0
+	// if fill is left out.
+	addOperator(opMap,
+		&Operator{
+			symbol:      makeVector,
+			minArgCount: 1,
+			maxArgCount: 2,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				n, err := intArg(makeVector, operands[0])
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				if n < 0 || n > maxVectorLength {
+					retVal.Err = newEvalError("Cannot make a vector of %d elements.", n)
+					return retVal
+				}
+				var fill Value = intValue{}
+				if len(operands) == 2 {
+					fill = operands[1].Val
+				}
+				elems := make([]Value, n)
+				for i := range elems {
+					elems[i] = fill
+				}
+				retVal.Val = newVectorValue(elems)
+				return retVal
+			},
+		},
+	)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      vectorRef,
+			minArgCount: 2,
+			maxArgCount: 2,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				v, err := vectorArg(vectorRef, operands[0])
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				i, err := vectorIndexArg(vectorRef, v, operands[1], false)
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				retVal.Val = v.elems[i]
+				return retVal
+			},
+		},
+	)
+
+	// (vector-set! v i x) changes the element at position i of v to x, and
+	// returns x, the way set! returns the new value.
+	addOperator(opMap,
+		&Operator{
+			symbol:      vectorSet,
+			minArgCount: 3,
+			maxArgCount: 3,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				v, err := vectorArg(vectorSet, operands[0])
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				i, err := vectorIndexArg(vectorSet, v, operands[1], false)
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				if containsVector(operands[2].Val, v) {
+					retVal.Err = newEvalError("Cannot store %s in itself.", v.Str())
+					return retVal
+				}
+				v.elems[i] = operands[2].Val
+				retVal.Val = operands[2].Val
+				return retVal
+			},
+		},
+	)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      vectorLength,
+			minArgCount: 1,
+			maxArgCount: 1,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				v, err := vectorArg(vectorLength, operands[0])
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				var val intValue
+				val.value = int64(len(v.elems))
+				retVal.Val = val
+				return retVal
+			},
+		},
+	)
+
+	// (vector-fill! v x) changes every element of v to x, and returns v.
+	addOperator(opMap,
+		&Operator{
+			symbol:      vectorFill,
+			minArgCount: 2,
+			maxArgCount: 2,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				v, err := vectorArg(vectorFill, operands[0])
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				if containsVector(operands[1].Val, v) {
+					retVal.Err = newEvalError("Cannot store %s in itself.", v.Str())
+					return retVal
+				}
+				for i := range v.elems {
+					v.elems[i] = operands[1].Val
+				}
+				retVal.Val = v
+				return retVal
+			},
+		},
+	)
+
+	// (vector-slice v start end) is a new vector with the elements of v from
+	// position start, up to the one at end. end is the end of v, if it is left
+	// out.
+	addOperator(opMap,
+		&Operator{
+			symbol:      vectorSlice,
+			minArgCount: 2,
+			maxArgCount: 3,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				v, err := vectorArg(vectorSlice, operands[0])
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				bounds := []int64{0, int64(len(v.elems))}
+				for i, o := range operands[1:] {
+					if bounds[i], err = vectorIndexArg(vectorSlice, v, o, true); err != nil {
+						retVal.Err = err
+						return retVal
+					}
+				}
+				if bounds[0] > bounds[1] {
+					retVal.Err = newEvalError("Cannot take the elements from %d to %d of %s.",
+						bounds[0], bounds[1], v.Str())
+					return retVal
+				}
+				elems := make([]Value, bounds[1]-bounds[0])
+				copy(elems, v.elems[bounds[0]:bounds[1]])
+				retVal.Val = newVectorValue(elems)
+				return retVal
+			},
+		},
+	)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      vectorToList,
+			minArgCount: 1,
+			maxArgCount: 1,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				v, err := vectorArg(vectorToList, operands[0])
+				if err != nil {
+					retVal.Err = err
+					return retVal
+				}
+				retVal.Val = newList(v.elems)
+				return retVal
+			},
+		},
+	)
+
+	addOperator(opMap,
+		&Operator{
+			symbol:      listToVector,
+			minArgCount: 1,
+			maxArgCount: 1,
+			handler: func(env *LangEnv, operands []Atom) Atom {
+				var retVal Atom
+				retVal.Val, retVal.Err = operands[0].Val.to(vectorType)
+				return retVal
+			},
+		},
+	)
+}